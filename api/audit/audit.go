@@ -0,0 +1,122 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit provides the append-only log of API operations required
+// when the engine runs as a shared service.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"log/syslog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Entry is one recorded API operation: who performed which action against
+// which object, and when.
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Object    string    `json:"object"`
+	Details   string    `json:"details,omitempty"`
+}
+
+// Logger appends API operations to an on-disk journal and optionally
+// forwards them to syslog for SIEM collection.
+type Logger struct {
+	sync.Mutex
+	path   string
+	file   *os.File
+	syslog *syslog.Writer
+}
+
+// NewLogger opens the append-only audit journal at the provided path. When
+// forward is true, entries are also written to the local syslog daemon.
+func NewLogger(path string, forward bool) (*Logger, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Logger{path: path, file: f}
+	if forward {
+		if w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, "amass-engine"); err == nil {
+			l.syslog = w
+		}
+	}
+	return l, nil
+}
+
+// Record appends one operation to the audit log.
+func (l *Logger) Record(actor, action, object, details string) {
+	entry := &Entry{
+		Timestamp: time.Now().UTC(),
+		Actor:     actor,
+		Action:    action,
+		Object:    object,
+		Details:   details,
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	_, _ = l.file.Write(append(data, '\n'))
+	if l.syslog != nil {
+		_ = l.syslog.Info(string(data))
+	}
+}
+
+// Query returns the recorded entries matching the provided filters; empty
+// filter values match everything. Results are capped at the provided limit
+// when it is greater than zero.
+func (l *Logger) Query(actor, action string, limit int) ([]*Entry, error) {
+	l.Lock()
+	defer l.Unlock()
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var results []*Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		if actor != "" && entry.Actor != actor {
+			continue
+		}
+		if action != "" && entry.Action != action {
+			continue
+		}
+
+		results = append(results, &entry)
+		if limit > 0 && len(results) >= limit {
+			break
+		}
+	}
+	return results, scanner.Err()
+}
+
+// Close releases the journal file and syslog connection.
+func (l *Logger) Close() {
+	l.Lock()
+	defer l.Unlock()
+
+	_ = l.file.Close()
+	if l.syslog != nil {
+		_ = l.syslog.Close()
+	}
+}
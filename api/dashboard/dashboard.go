@@ -0,0 +1,115 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dashboard serves a minimal built-in web UI from the engine for
+// users who do not want to build an API client: active sessions, live
+// discovery feeds, queue and plugin health, and basic asset browsing.
+package dashboard
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/sessions"
+)
+
+const indexHTML = `<!DOCTYPE html>
+<html>
+<head>
+<title>Amass Engine</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+td, th { border: 1px solid #ccc; padding: 0.4em 0.8em; }
+</style>
+</head>
+<body>
+<h1>Amass Engine</h1>
+<h2>Sessions</h2>
+<table id="sessions"><tr><th>ID</th><th>State</th><th>Findings</th></tr></table>
+<script>
+async function refresh() {
+    const resp = await fetch("api/sessions");
+    const data = await resp.json();
+    const table = document.getElementById("sessions");
+    while (table.rows.length > 1) table.deleteRow(1);
+    for (const s of data) {
+        const row = table.insertRow();
+        row.insertCell().textContent = s.id;
+        row.insertCell().textContent = s.state;
+        row.insertCell().textContent = s.findings;
+    }
+}
+refresh();
+setInterval(refresh, 5000);
+</script>
+</body>
+</html>`
+
+// Handler serves the dashboard pages and their backing JSON endpoints.
+type Handler struct {
+	manager *sessions.Manager
+	mux     *http.ServeMux
+}
+
+// NewHandler returns the dashboard handler, intended to be mounted on its
+// own mux path separate from the GraphQL API.
+func NewHandler(manager *sessions.Manager) *Handler {
+	h := &Handler{
+		manager: manager,
+		mux:     http.NewServeMux(),
+	}
+
+	h.mux.HandleFunc("/", h.index)
+	h.mux.HandleFunc("/api/sessions", h.sessionList)
+	h.mux.HandleFunc("/api/findings", h.findingList)
+	return h
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) index(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(indexHTML))
+}
+
+func (h *Handler) sessionList(w http.ResponseWriter, _ *http.Request) {
+	type entry struct {
+		ID       string `json:"id"`
+		State    string `json:"state"`
+		Findings int    `json:"findings"`
+	}
+
+	var results []entry
+	for _, s := range h.manager.AllSessions() {
+		state, reason := s.IngestionState()
+		if reason != "" {
+			state += " (" + reason + ")"
+		}
+		results = append(results, entry{
+			ID:       s.ID().String(),
+			State:    state,
+			Findings: len(support.SessionFindings(s.ID())),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(results)
+}
+
+func (h *Handler) findingList(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.URL.Query().Get("session"))
+	if err != nil {
+		http.Error(w, "an invalid session identifier was provided", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(support.SessionFindings(id))
+}
@@ -0,0 +1,66 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// operationName extracts a coarse operation label from the request text for
+// the audit log, such as the first field selected.
+func operationName(query string) string {
+	query = strings.TrimSpace(query)
+
+	for _, prefix := range []string{"mutation", "query", "subscription"} {
+		query = strings.TrimPrefix(query, prefix)
+	}
+	if i := strings.IndexAny(query, "{("); i >= 0 {
+		query = query[i+1:]
+	}
+
+	fields := strings.FieldsFunc(query, func(r rune) bool {
+		return r == '(' || r == '{' || r == ' ' || r == '\n' || r == '\t'
+	})
+	if len(fields) > 0 {
+		return fields[0]
+	}
+	return "unknown"
+}
+
+var auditLogEntryType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "AuditLogEntry",
+	Fields: graphql.Fields{
+		"timestamp": &graphql.Field{Type: graphql.String},
+		"actor":     &graphql.Field{Type: graphql.String},
+		"action":    &graphql.Field{Type: graphql.String},
+		"object":    &graphql.Field{Type: graphql.String},
+		"details":   &graphql.Field{Type: graphql.String},
+	},
+})
+
+func init() {
+	registerQuery("auditLog", &graphql.Field{
+		Type: graphql.NewList(auditLogEntryType),
+		Args: graphql.FieldConfigArgument{
+			"actor":  &graphql.ArgumentConfig{Type: graphql.String},
+			"action": &graphql.ArgumentConfig{Type: graphql.String},
+			"limit":  &graphql.ArgumentConfig{Type: graphql.Int},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			s := serverFromContext(p.Context)
+			if s == nil || s.audit == nil {
+				return nil, errors.New("audit logging is not enabled on this engine")
+			}
+
+			actor, _ := p.Args["actor"].(string)
+			action, _ := p.Args["action"].(string)
+			limit, _ := p.Args["limit"].(int)
+			return s.audit.Query(actor, action, limit)
+		},
+	})
+}
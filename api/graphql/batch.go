@@ -0,0 +1,131 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// Typed error codes returned per item by the batch operations. Codes
+// marked retryable indicate transient conditions; the others will fail
+// again on resubmission until the input or configuration changes.
+const (
+	BatchOK          = "OK"
+	BatchInvalidName = "INVALID_NAME" // permanent: fix the input
+	BatchOutOfScope  = "OUT_OF_SCOPE" // permanent: adjust the scope
+	BatchDuplicate   = "DUPLICATE"    // permanent: already known
+	BatchInternal    = "INTERNAL"     // retryable: transient engine error
+)
+
+var batchItemResultType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "BatchItemResult",
+	Description: "The outcome for one item of a batch operation. A failed " +
+		"item never aborts the rest of the batch: resubmit only the items " +
+		"whose code marks a retryable condition (INTERNAL); the other codes " +
+		"are permanent for the same input and configuration.",
+	Fields: graphql.Fields{
+		"item":    &graphql.Field{Type: graphql.String},
+		"ok":      &graphql.Field{Type: graphql.Boolean},
+		"code":    &graphql.Field{Type: graphql.String},
+		"message": &graphql.Field{Type: graphql.String},
+	},
+})
+
+func batchResult(item string, code, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"item":    item,
+		"ok":      code == BatchOK,
+		"code":    code,
+		"message": message,
+	}
+}
+
+func init() {
+	registerMutation("submitAssets", &graphql.Field{
+		Type: graphql.NewList(batchItemResultType),
+		Description: "Submits a batch of FQDNs into the session pipelines. " +
+			"Each item succeeds or fails independently with a typed code.",
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"names": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.String)),
+			},
+			"correlationId": &graphql.ArgumentConfig{
+				Type: graphql.String,
+				Description: "An opaque ID carried through every event derived " +
+					"from these seeds; a correlation:complete finding (and the " +
+					"configured webhook) fires once the subtree finishes.",
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+			d := serverFromContext(p.Context).Dispatcher()
+			correlation, _ := p.Args["correlationId"].(string)
+
+			items, _ := p.Args["names"].([]interface{})
+			results := make([]map[string]interface{}, 0, len(items))
+			var events []*types.Event
+			for _, item := range items {
+				name, _ := item.(string)
+				result, e := submitOne(session, d, name, correlation)
+				results = append(results, result)
+				if e != nil {
+					events = append(events, e)
+				}
+			}
+
+			// The per-item codes only reflect validation and storage, so
+			// the accepted events are dispatched in the background and the
+			// mutation returns without waiting on the pipelines.
+			go func() {
+				for _, e := range events {
+					_ = d.DispatchEvent(e)
+				}
+			}()
+			return results, nil
+		},
+	})
+}
+
+// submitOne validates and stores a single batch item, mapping each failure
+// to its typed code. Accepted items return the event ready for dispatch.
+func submitOne(session types.Session, d types.Dispatcher, name, correlation string) (map[string]interface{}, *types.Event) {
+	name = support.InternFQDN(session.ID(), name)
+
+	if err := support.ValidateHostname(name); err != nil {
+		return batchResult(name, BatchInvalidName, err.Error()), nil
+	}
+	if !session.Config().IsDomainInScope(name) {
+		return batchResult(name, BatchOutOfScope, "the name is not in the session scope"), nil
+	}
+	if support.KnownFQDN(session.ID(), name) {
+		return batchResult(name, BatchDuplicate, "the name was already submitted"), nil
+	}
+	if d == nil {
+		return batchResult(name, BatchInternal, "the engine dispatcher is unavailable"), nil
+	}
+
+	a, err := session.Cache().Create(nil, "", &oamdns.FQDN{Name: name})
+	if err != nil || a == nil {
+		return batchResult(name, BatchInternal, "failed to store the asset"), nil
+	}
+
+	e := types.AcquireEvent()
+	e.Name = name
+	e.Asset = a
+	e.Timestamp = time.Now()
+	e.Session = session
+	e.Dispatcher = d
+	e.Correlation = correlation
+	return batchResult(name, BatchOK, ""), e
+}
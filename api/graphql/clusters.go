@@ -0,0 +1,42 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/owasp-amass/engine/plugins/web"
+)
+
+var tlsClusterType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "TLSCluster",
+	Fields: graphql.Fields{
+		"fingerprint": &graphql.Field{Type: graphql.String},
+		"hosts":       &graphql.Field{Type: graphql.NewList(graphql.String)},
+	},
+})
+
+func init() {
+	registerQuery("tlsClusters", &graphql.Field{
+		Type: graphql.NewList(tlsClusterType),
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			var results []map[string]interface{}
+			for _, c := range web.TLSClusters(session.ID()) {
+				results = append(results, map[string]interface{}{
+					"fingerprint": c.Fingerprint,
+					"hosts":       c.Hosts,
+				})
+			}
+			return results, nil
+		},
+	})
+}
@@ -0,0 +1,48 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/sessions"
+)
+
+type contextKey int
+
+const serverKey contextKey = iota
+
+func contextWithServer(ctx context.Context, s *Server) context.Context {
+	return context.WithValue(ctx, serverKey, s)
+}
+
+func serverFromContext(ctx context.Context) *Server {
+	if s, ok := ctx.Value(serverKey).(*Server); ok {
+		return s
+	}
+	return nil
+}
+
+// sessionFromArgs resolves the sessionToken argument common to most of the
+// API operations into the session it identifies.
+func sessionFromArgs(ctx context.Context, args map[string]interface{}) (*sessions.Session, error) {
+	token, _ := args["sessionToken"].(string)
+
+	id, err := uuid.Parse(token)
+	if err != nil {
+		return nil, errors.New("an invalid session token was provided")
+	}
+
+	s := serverFromContext(ctx)
+	if s == nil {
+		return nil, errors.New("the request context is missing the server")
+	}
+	if session := s.Manager().GetSession(id); session != nil {
+		return session, nil
+	}
+	return nil, errors.New("no session matches the provided token")
+}
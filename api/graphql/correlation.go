@@ -0,0 +1,155 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/sessions"
+)
+
+var sharedAssetType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "SharedAsset",
+	Fields: graphql.Fields{
+		"asset":    &graphql.Field{Type: graphql.String},
+		"sessions": &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"sources":  &graphql.Field{Type: graphql.NewList(graphql.String)},
+	},
+})
+
+// sessionsFromTokens resolves a list of session tokens against the manager.
+func sessionsFromTokens(ctx context.Context, tokens []interface{}) ([]*sessions.Session, error) {
+	s := serverFromContext(ctx)
+	if s == nil {
+		return nil, errors.New("the request context is missing the server")
+	}
+
+	var results []*sessions.Session
+	for _, t := range tokens {
+		token, _ := t.(string)
+
+		id, err := uuid.Parse(token)
+		if err != nil {
+			return nil, errors.New("an invalid session token was provided")
+		}
+		session := s.Manager().GetSession(id)
+		if session == nil {
+			return nil, errors.New("no session matches the provided token")
+		}
+		results = append(results, session)
+	}
+	return results, nil
+}
+
+// assetSources collects the sources that contributed the named asset within
+// one session, combining finding records and recorded provenance.
+func assetSources(id uuid.UUID, asset string) []string {
+	seen := make(map[string]struct{})
+
+	for _, f := range support.SessionFindings(id) {
+		if f.Asset == asset && f.Source != "" {
+			seen[f.Source] = struct{}{}
+		}
+	}
+	if prov := support.AssetProvenance(id, asset); prov != nil && prov.Source != "" {
+		seen[prov.Source] = struct{}{}
+	}
+
+	var results []string
+	for src := range seen {
+		results = append(results, src)
+	}
+	return results
+}
+
+// sessionAssets returns the set of asset names seen by a session.
+func sessionAssets(id uuid.UUID) map[string]struct{} {
+	assets := make(map[string]struct{})
+	for _, f := range support.SessionFindings(id) {
+		assets[f.Asset] = struct{}{}
+	}
+	return assets
+}
+
+func init() {
+	registerQuery("sharedAssets", &graphql.Field{
+		Type: graphql.NewList(sharedAssetType),
+		Args: graphql.FieldConfigArgument{
+			"sessionTokens": &graphql.ArgumentConfig{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.String)),
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			tokens, _ := p.Args["sessionTokens"].([]interface{})
+
+			slist, err := sessionsFromTokens(p.Context, tokens)
+			if err != nil {
+				return nil, err
+			}
+
+			counts := make(map[string][]string)
+			for _, session := range slist {
+				id := session.ID()
+				for asset := range sessionAssets(id) {
+					counts[asset] = append(counts[asset], id.String())
+				}
+			}
+
+			var results []map[string]interface{}
+			for asset, ids := range counts {
+				if len(ids) < 2 {
+					continue
+				}
+
+				var sources []string
+				for _, session := range slist {
+					sources = append(sources, assetSources(session.ID(), asset)...)
+				}
+				results = append(results, map[string]interface{}{
+					"asset":    asset,
+					"sessions": ids,
+					"sources":  sources,
+				})
+			}
+			return results, nil
+		},
+	})
+
+	registerQuery("newAssets", &graphql.Field{
+		Type: graphql.NewList(sharedAssetType),
+		Args: graphql.FieldConfigArgument{
+			"baseToken":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"compareToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			slist, err := sessionsFromTokens(p.Context, []interface{}{
+				p.Args["baseToken"], p.Args["compareToken"],
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			base := sessionAssets(slist[0].ID())
+			compare := slist[1].ID()
+
+			var results []map[string]interface{}
+			for asset := range sessionAssets(compare) {
+				if _, found := base[asset]; found {
+					continue
+				}
+				results = append(results, map[string]interface{}{
+					"asset":    asset,
+					"sessions": []string{compare.String()},
+					"sources":  assetSources(compare, asset),
+				})
+			}
+			return results, nil
+		},
+	})
+}
@@ -0,0 +1,30 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+func init() {
+	registerQuery("exportZone", &graphql.Field{
+		Type: graphql.String,
+		Description: "Renders the DNS records collected for an in-scope zone " +
+			"as zone-file formatted text.",
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"zone":         &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			zone, _ := p.Args["zone"].(string)
+			return session.ExportZone(zone)
+		},
+	})
+}
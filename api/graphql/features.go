@@ -0,0 +1,59 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"errors"
+
+	"github.com/graphql-go/graphql"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+var featureType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Feature",
+	Fields: graphql.Fields{
+		"name":        &graphql.Field{Type: graphql.String},
+		"enabled":     &graphql.Field{Type: graphql.Boolean},
+		"description": &graphql.Field{Type: graphql.String},
+	},
+})
+
+func init() {
+	registerQuery("features", &graphql.Field{
+		Type: graphql.NewList(featureType),
+		Description: "Lists the gated subsystems with their effective " +
+			"engine-wide state; sessions override with feature/<name> options.",
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var results []map[string]interface{}
+			for _, f := range support.Features() {
+				results = append(results, map[string]interface{}{
+					"name":        f.Name,
+					"enabled":     f.Enabled,
+					"description": f.Description,
+				})
+			}
+			return results, nil
+		},
+	})
+
+	registerMutation("setFeature", &graphql.Field{
+		Type: graphql.Boolean,
+		Description: "Overrides a feature's default for the whole engine; " +
+			"per-session overrides use the feature/<name> option instead.",
+		Args: graphql.FieldConfigArgument{
+			"name":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"enabled": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Boolean)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			name, _ := p.Args["name"].(string)
+			enabled, _ := p.Args["enabled"].(bool)
+
+			if !support.SetFeature(name, enabled) {
+				return nil, errors.New("no feature matches the provided name")
+			}
+			return true, nil
+		},
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"errors"
+
+	"github.com/graphql-go/graphql"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+var findingType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "Finding",
+	Fields: graphql.Fields{
+		"id":          &graphql.Field{Type: graphql.Int},
+		"type":        &graphql.Field{Type: graphql.String},
+		"asset":       &graphql.Field{Type: graphql.String},
+		"description": &graphql.Field{Type: graphql.String},
+		"severity":    &graphql.Field{Type: graphql.String},
+		"source":      &graphql.Field{Type: graphql.String},
+		"status":      &graphql.Field{Type: graphql.String},
+		"assignee":    &graphql.Field{Type: graphql.String},
+		"suppressed":  &graphql.Field{Type: graphql.Boolean},
+	},
+})
+
+func init() {
+	registerQuery("sessionFindings", &graphql.Field{
+		Type: graphql.NewList(findingType),
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"status":       &graphql.ArgumentConfig{Type: graphql.String},
+			"severity":     &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+			status, _ := p.Args["status"].(string)
+			severity, _ := p.Args["severity"].(string)
+
+			// The triage view lists suppressed findings too, flagged, with
+			// indices matching what updateFinding expects.
+			var results []map[string]interface{}
+			for i, f := range support.AllSessionFindings(session.ID()) {
+				if status != "" && f.Status != status {
+					continue
+				}
+				if severity != "" && f.Severity != severity {
+					continue
+				}
+				results = append(results, map[string]interface{}{
+					"id":          i,
+					"type":        f.Type,
+					"asset":       f.Asset,
+					"description": f.Description,
+					"severity":    f.Severity,
+					"source":      f.Source,
+					"status":      f.Status,
+					"assignee":    f.Assignee,
+					"suppressed":  f.Suppressed,
+				})
+			}
+			return results, nil
+		},
+	})
+
+	registerMutation("updateFinding", &graphql.Field{
+		Type: graphql.Boolean,
+		Description: "Updates the workflow fields of one finding. Valid " +
+			"statuses are: new, triaged, resolved, and false positive.",
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"id":           &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+			"status":       &graphql.ArgumentConfig{Type: graphql.String},
+			"assignee":     &graphql.ArgumentConfig{Type: graphql.String},
+			"severity":     &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return false, err
+			}
+
+			id, _ := p.Args["id"].(int)
+			status, _ := p.Args["status"].(string)
+			assignee, _ := p.Args["assignee"].(string)
+			severity, _ := p.Args["severity"].(string)
+
+			if !support.UpdateFinding(session.ID(), id, status, assignee, severity) {
+				return false, errors.New("no finding matches the provided ID and status")
+			}
+			return true, nil
+		},
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+func init() {
+	registerQuery("exportTargets", &graphql.Field{
+		Type: graphql.NewList(graphql.String),
+		Description: "Produces a target list consumable by httpx and nuclei: " +
+			"in-scope hosts annotated with observed schemes and ports. An " +
+			"optional tag restricts the list to hosts carrying it.",
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"tag":          &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			tag, _ := p.Args["tag"].(string)
+			return session.ExportTargets(tag), nil
+		},
+	})
+
+	registerMutation("importHttpx", &graphql.Field{
+		Type: graphql.Int,
+		Description: "Imports httpx JSONL output, recording confirmed services " +
+			"as asset properties. Returns the number of imported lines.",
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"jsonl":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonl, _ := p.Args["jsonl"].(string)
+			return session.ImportHTTPX(strings.NewReader(jsonl))
+		},
+	})
+
+	registerMutation("importNuclei", &graphql.Field{
+		Type: graphql.Int,
+		Description: "Imports nuclei JSONL output, recording each match through " +
+			"the findings layer. Returns the number of imported lines.",
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"jsonl":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			jsonl, _ := p.Args["jsonl"].(string)
+			return session.ImportNuclei(strings.NewReader(jsonl))
+		},
+	})
+}
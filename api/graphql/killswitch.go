@@ -0,0 +1,35 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+func init() {
+	registerMutation("emergencyStop", &graphql.Field{
+		Type: graphql.Boolean,
+		Args: graphql.FieldConfigArgument{
+			"reason": &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			reason, _ := p.Args["reason"].(string)
+			if reason == "" {
+				reason = "requested through the API"
+			}
+
+			support.TriggerEmergencyStop(reason)
+			return true, nil
+		},
+	})
+
+	registerQuery("emergencyStopped", &graphql.Field{
+		Type: graphql.Boolean,
+		Resolve: func(_ graphql.ResolveParams) (interface{}, error) {
+			return support.EmergencyStopped(), nil
+		},
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+var mergeProposalType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "MergeProposal",
+	Fields: graphql.Fields{
+		"kept":      &graphql.Field{Type: graphql.String},
+		"discarded": &graphql.Field{Type: graphql.String},
+		"reason":    &graphql.Field{Type: graphql.String},
+	},
+})
+
+var maintenanceReportType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "MaintenanceReport",
+	Fields: graphql.Fields{
+		"dryRun":    &graphql.Field{Type: graphql.Boolean},
+		"examined":  &graphql.Field{Type: graphql.Int},
+		"proposals": &graphql.Field{Type: graphql.NewList(mergeProposalType)},
+	},
+})
+
+func init() {
+	registerMutation("runMaintenance", &graphql.Field{
+		Type: maintenanceReportType,
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"dryRun": &graphql.ArgumentConfig{
+				Type:         graphql.Boolean,
+				DefaultValue: true,
+			},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			dryRun, _ := p.Args["dryRun"].(bool)
+			report, err := session.RunMaintenance(dryRun)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{
+				"dryRun":    report.DryRun,
+				"examined":  report.Examined,
+				"proposals": report.Proposals,
+			}, nil
+		},
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+func init() {
+	registerMutation("mutateSession", &graphql.Field{
+		Type: graphql.Boolean,
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"options":      &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+			"addDomains":   &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+			"addWordlist":  &graphql.ArgumentConfig{Type: graphql.NewList(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return false, err
+			}
+
+			// Options arrive as "name=value" pairs.
+			options := make(map[string]interface{})
+			for _, raw := range stringList(p.Args["options"]) {
+				if name, value, found := cutOption(raw); found {
+					options[name] = value
+				}
+			}
+
+			session.Reconfigure(options,
+				stringList(p.Args["addDomains"]), stringList(p.Args["addWordlist"]))
+			return true, nil
+		},
+	})
+}
+
+func stringList(arg interface{}) []string {
+	var results []string
+
+	if list, ok := arg.([]interface{}); ok {
+		for _, v := range list {
+			if s, ok := v.(string); ok {
+				results = append(results, s)
+			}
+		}
+	}
+	return results
+}
+
+func cutOption(raw string) (string, string, bool) {
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '=' {
+			return raw[:i], raw[i+1:], true
+		}
+	}
+	return "", "", false
+}
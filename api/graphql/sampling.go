@@ -0,0 +1,46 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+var zoneSamplingType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ZoneSampling",
+	Fields: graphql.Fields{
+		"zone":      &graphql.Field{Type: graphql.String},
+		"processed": &graphql.Field{Type: graphql.Int},
+		"skipped":   &graphql.Field{Type: graphql.Int},
+		"rate":      &graphql.Field{Type: graphql.Int},
+	},
+})
+
+func init() {
+	registerQuery("samplingReport", &graphql.Field{
+		Type: graphql.NewList(zoneSamplingType),
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			var results []map[string]interface{}
+			for zone, z := range support.SamplingReport(session.ID()) {
+				results = append(results, map[string]interface{}{
+					"zone":      zone,
+					"processed": z.Processed,
+					"skipped":   z.Skipped,
+					"rate":      z.Rate,
+				})
+			}
+			return results, nil
+		},
+	})
+}
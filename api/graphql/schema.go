@@ -0,0 +1,113 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+)
+
+// SchemaVersion identifies the API schema served by this engine build.
+// External tooling should compare it before assuming field availability;
+// deprecated fields carry deprecation reasons in the introspection output.
+const SchemaVersion = "1.0.0"
+
+// introspectionQuery is the standard GraphQL introspection query, inlined
+// so serving the schema does not pull test helpers into the binary.
+const introspectionQuery = `
+query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives {
+      name
+      description
+      locations
+      args { ...InputValue }
+    }
+  }
+}
+fragment FullType on __Type {
+  kind
+  name
+  description
+  fields(includeDeprecated: true) {
+    name
+    description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes { ...TypeRef }
+}
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+        ofType {
+          kind
+          name
+          ofType {
+            kind
+            name
+            ofType {
+              kind
+              name
+              ofType {
+                kind
+                name
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+// SchemaHandler serves the schema endpoint used by SDK generators: the
+// full introspection result, annotated with the schema version, so typed
+// clients can be regenerated when the API evolves.
+func (s *Server) SchemaHandler(w http.ResponseWriter, _ *http.Request) {
+	result := graphql.Do(graphql.Params{
+		Schema:        s.schema,
+		RequestString: introspectionQuery,
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Schema-Version", SchemaVersion)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"version": SchemaVersion,
+		"schema":  result.Data,
+	})
+}
@@ -0,0 +1,51 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+var scopeCandidateType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "ScopeCandidate",
+	Fields: graphql.Fields{
+		"value":    &graphql.Field{Type: graphql.String},
+		"source":   &graphql.Field{Type: graphql.String},
+		"reason":   &graphql.Field{Type: graphql.String},
+		"proposed": &graphql.Field{Type: graphql.String},
+	},
+})
+
+func init() {
+	registerQuery("pendingScopeCandidates", &graphql.Field{
+		Type: graphql.NewList(scopeCandidateType),
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+			return support.PendingScopeCandidates(session.ID()), nil
+		},
+	})
+
+	registerMutation("approveScopeCandidate", &graphql.Field{
+		Type: graphql.Boolean,
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"value":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return false, err
+			}
+			return support.ApproveScopeCandidate(session, p.Args["value"].(string)), nil
+		},
+	})
+}
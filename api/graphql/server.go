@@ -0,0 +1,110 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/graphql-go/graphql"
+	"github.com/owasp-amass/engine/api/audit"
+	"github.com/owasp-amass/engine/api/auth"
+	"github.com/owasp-amass/engine/sessions"
+	"github.com/owasp-amass/engine/types"
+)
+
+// Field maps assembled into the schema; feature files append their queries
+// and mutations during package initialization.
+var queryFields = graphql.Fields{}
+var mutationFields = graphql.Fields{}
+
+func registerQuery(name string, field *graphql.Field) {
+	queryFields[name] = field
+}
+
+func registerMutation(name string, field *graphql.Field) {
+	mutationFields[name] = field
+}
+
+// Server exposes the engine API over GraphQL.
+type Server struct {
+	manager    *sessions.Manager
+	schema     graphql.Schema
+	audit      *audit.Logger
+	dispatcher types.Dispatcher
+}
+
+// NewServer builds the GraphQL schema and returns the API server.
+func NewServer(manager *sessions.Manager) (*Server, error) {
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: queryFields,
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Mutation",
+			Fields: mutationFields,
+		}),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Server{
+		manager: manager,
+		schema:  schema,
+	}, nil
+}
+
+// SetAuditLogger enables audit logging of all API operations.
+func (s *Server) SetAuditLogger(l *audit.Logger) {
+	s.audit = l
+}
+
+// SetDispatcher provides the dispatcher used by operations submitting
+// assets into the pipelines.
+func (s *Server) SetDispatcher(d types.Dispatcher) {
+	s.dispatcher = d
+}
+
+// Dispatcher returns the dispatcher serving API asset submissions.
+func (s *Server) Dispatcher() types.Dispatcher {
+	return s.dispatcher
+}
+
+// Manager returns the session manager serving this API.
+func (s *Server) Manager() *sessions.Manager {
+	return s.manager
+}
+
+// ServeHTTP handles a single GraphQL request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  body.Query,
+		VariableValues: body.Variables,
+		Context:        contextWithServer(r.Context(), s),
+	})
+
+	if s.audit != nil {
+		actor := auth.SubjectFromContext(r.Context())
+		if actor == "" {
+			actor = r.RemoteAddr
+		}
+		s.audit.Record(actor, "graphql", operationName(body.Query), "")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,87 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+// ssePollInterval is how often the handler checks for new findings and
+// emits a keepalive comment on an idle stream.
+const ssePollInterval = 2 * time.Second
+
+// SSEHandler streams session findings as Server-Sent Events, a simpler
+// alternative to the websocket subscription for clients like curl and
+// browsers behind proxies. Event IDs are finding sequence numbers, so a
+// reconnecting client resumes from its Last-Event-ID header.
+func (s *Server) SSEHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.URL.Query().Get("sessionToken"))
+	if err != nil {
+		http.Error(w, "an invalid session token was provided", http.StatusBadRequest)
+		return
+	}
+	session := s.manager.GetSession(id)
+	if session == nil {
+		http.Error(w, "no session matches the provided token", http.StatusNotFound)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	var sent int
+	if last := r.Header.Get("Last-Event-ID"); last != "" {
+		if n, err := strconv.Atoi(last); err == nil && n > 0 {
+			sent = n
+		}
+	}
+
+	t := time.NewTicker(ssePollInterval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-t.C:
+		}
+
+		findings := support.SessionFindings(id)
+		if len(findings) == sent {
+			// A comment line keeps intermediaries from timing out the
+			// connection while no results arrive.
+			fmt.Fprint(w, ": keepalive\n\n")
+			flusher.Flush()
+		}
+		for ; sent < len(findings); sent++ {
+			data, err := json.Marshal(findings[sent])
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "id: %d\nevent: finding\ndata: %s\n\n", sent+1, data)
+			flusher.Flush()
+		}
+
+		if session.Done() {
+			fmt.Fprint(w, "event: complete\ndata: {}\n\n")
+			flusher.Flush()
+			return
+		}
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+const (
+	// heartbeatInterval is how often the server emits a keepalive message
+	// on an otherwise idle subscription stream.
+	heartbeatInterval = 30 * time.Second
+	// pongWait is how long the server waits for a client pong before it
+	// considers the websocket dead and drops it.
+	pongWait  = 90 * time.Second
+	writeWait = 10 * time.Second
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+}
+
+// streamMessage is the envelope for every frame sent on the subscription
+// stream. Heartbeat frames carry liveness info so clients can distinguish
+// "no results yet" from a connection that silently died.
+type streamMessage struct {
+	Type     string      `json:"type"`
+	Session  string      `json:"session,omitempty"`
+	Active   bool        `json:"active,omitempty"`
+	Findings int         `json:"findings,omitempty"`
+	Payload  interface{} `json:"payload,omitempty"`
+}
+
+// SubscriptionHandler upgrades the connection to a websocket and streams
+// session findings to the client, interleaved with periodic heartbeats.
+func (s *Server) SubscriptionHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.URL.Query().Get("sessionToken"))
+	if err != nil {
+		http.Error(w, "an invalid session token was provided", http.StatusBadRequest)
+		return
+	}
+	session := s.manager.GetSession(id)
+	if session == nil {
+		http.Error(w, "no session matches the provided token", http.StatusNotFound)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// The read loop exists only to process pong frames and detect a dead
+	// peer; subscription clients do not send application messages.
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	t := time.NewTicker(heartbeatInterval)
+	defer t.Stop()
+
+	var sent int
+	for {
+		select {
+		case <-closed:
+			return
+		case <-t.C:
+		}
+
+		findings := support.SessionFindings(id)
+		for ; sent < len(findings); sent++ {
+			if err := writeStream(conn, &streamMessage{
+				Type:    "finding",
+				Session: id.String(),
+				Payload: findings[sent],
+			}); err != nil {
+				return
+			}
+		}
+
+		if err := writeStream(conn, &streamMessage{
+			Type:     "heartbeat",
+			Session:  id.String(),
+			Active:   !session.Done(),
+			Findings: len(findings),
+		}); err != nil {
+			return
+		}
+		_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+		if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+			return
+		}
+
+		if session.Done() {
+			return
+		}
+	}
+}
+
+func writeStream(conn *websocket.Conn, msg *streamMessage) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return conn.WriteMessage(websocket.TextMessage, data)
+}
@@ -0,0 +1,45 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+func init() {
+	args := graphql.FieldConfigArgument{
+		"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		"asset":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+	}
+
+	registerMutation("suppressAsset", &graphql.Field{
+		Type: graphql.Boolean,
+		Args: args,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return false, err
+			}
+
+			asset := p.Args["asset"].(string)
+			support.SuppressAsset(session.Config(), asset)
+			support.AddTag(session.Config(), asset, "suppressed")
+			return true, nil
+		},
+	})
+
+	registerQuery("isSuppressed", &graphql.Field{
+		Type: graphql.Boolean,
+		Args: args,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+			return support.IsSuppressed(session.Config(), p.Args["asset"].(string)), nil
+		},
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+// Tags are kept in the persistent per-scope tag store, so analyst markings
+// survive engine restarts and reach later sessions covering the same zones.
+// Annotations ride the session's asset property store alongside the rest of
+// the per-asset metadata, and are freed with it when the session leaves
+// management.
+func init() {
+	tagArgs := graphql.FieldConfigArgument{
+		"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		"asset":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		"value":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+	}
+
+	registerMutation("addTag", &graphql.Field{
+		Type: graphql.Boolean,
+		Args: tagArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return false, err
+			}
+
+			support.AddTag(session.Config(), p.Args["asset"].(string), p.Args["value"].(string))
+			return true, nil
+		},
+	})
+
+	registerMutation("removeTag", &graphql.Field{
+		Type: graphql.Boolean,
+		Args: tagArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return false, err
+			}
+
+			support.RemoveTag(session.Config(), p.Args["asset"].(string), p.Args["value"].(string))
+			return true, nil
+		},
+	})
+
+	registerMutation("annotateAsset", &graphql.Field{
+		Type: graphql.Boolean,
+		Args: tagArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return false, err
+			}
+
+			support.AddProperty(session, p.Args["asset"].(string),
+				"annotation", p.Args["value"].(string))
+			return true, nil
+		},
+	})
+
+	registerQuery("assetTags", &graphql.Field{
+		Type: graphql.NewList(graphql.String),
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"asset":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+			return support.AssetTags(session.Config(), p.Args["asset"].(string)), nil
+		},
+	})
+
+	registerQuery("assetAnnotations", &graphql.Field{
+		Type: graphql.NewList(graphql.String),
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"asset":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			var results []string
+			for _, prop := range support.AssetProperties(session.ID(), p.Args["asset"].(string)) {
+				if prop.Name == "annotation" {
+					results = append(results, prop.Value)
+				}
+			}
+			return results, nil
+		},
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+var assetIntervalType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "AssetInterval",
+	Fields: graphql.Fields{
+		"asset":     &graphql.Field{Type: graphql.String},
+		"firstSeen": &graphql.Field{Type: graphql.String},
+		"lastSeen":  &graphql.Field{Type: graphql.String},
+	},
+})
+
+var relationIntervalType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "RelationInterval",
+	Fields: graphql.Fields{
+		"source":    &graphql.Field{Type: graphql.String},
+		"relation":  &graphql.Field{Type: graphql.String},
+		"target":    &graphql.Field{Type: graphql.String},
+		"firstSeen": &graphql.Field{Type: graphql.String},
+		"lastSeen":  &graphql.Field{Type: graphql.String},
+	},
+})
+
+var attackSurfaceType = graphql.NewObject(graphql.ObjectConfig{
+	Name: "AttackSurface",
+	Fields: graphql.Fields{
+		"assets":    &graphql.Field{Type: graphql.NewList(graphql.String)},
+		"relations": &graphql.Field{Type: graphql.NewList(relationIntervalType)},
+	},
+})
+
+func init() {
+	registerQuery("assetTimeline", &graphql.Field{
+		Type: assetIntervalType,
+		Description: "Returns when the asset first and last appeared in the " +
+			"session, for incident investigations against recurring monitoring.",
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"asset":        &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			asset, _ := p.Args["asset"].(string)
+			iv := support.AssetInterval(session.ID(), asset)
+			if iv == nil {
+				return nil, errors.New("the session never observed the asset")
+			}
+			return map[string]interface{}{
+				"asset":     asset,
+				"firstSeen": iv.FirstSeen.Format(time.RFC3339),
+				"lastSeen":  iv.LastSeen.Format(time.RFC3339),
+			}, nil
+		},
+	})
+
+	registerQuery("attackSurfaceAt", &graphql.Field{
+		Type: attackSurfaceType,
+		Description: "Reconstructs the assets and relations whose validity " +
+			"windows contained the RFC 3339 time.",
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"time":         &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			raw, _ := p.Args["time"].(string)
+			at, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return nil, errors.New("the time must be in RFC 3339 format")
+			}
+
+			var relations []map[string]interface{}
+			for _, r := range support.RelationsAt(session.ID(), at) {
+				relations = append(relations, map[string]interface{}{
+					"source":    r.Source,
+					"relation":  r.Relation,
+					"target":    r.Target,
+					"firstSeen": r.FirstSeen.Format(time.RFC3339),
+					"lastSeen":  r.LastSeen.Format(time.RFC3339),
+				})
+			}
+			return map[string]interface{}{
+				"assets":    support.AssetsAt(session.ID(), at),
+				"relations": relations,
+			}, nil
+		},
+	})
+}
@@ -0,0 +1,32 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+func init() {
+	registerMutation("warmStartSession", &graphql.Field{
+		Type: graphql.Int,
+		Description: "Seeds the session from an organization name alone: " +
+			"registrant, ASN, and certificate-organization lookups propose " +
+			"root domains and netblocks as scope candidates for approval. " +
+			"Returns the number of proposed candidates.",
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+			"organization": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+
+			org, _ := p.Args["organization"].(string)
+			return session.WarmStart(org)
+		},
+	})
+}
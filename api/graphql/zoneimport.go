@@ -0,0 +1,29 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package graphql
+
+import (
+	"github.com/graphql-go/graphql"
+)
+
+func init() {
+	registerMutation("importAuthorizedZones", &graphql.Field{
+		Type: graphql.Int,
+		Description: "Imports the organization's own authoritative DNS zones " +
+			"from every managed DNS provider with configured read-only " +
+			"credentials (Cloudflare, Route 53, Azure DNS, Google Cloud DNS) " +
+			"as high-confidence assets. Returns the number of records imported.",
+		Args: graphql.FieldConfigArgument{
+			"sessionToken": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			session, err := sessionFromArgs(p.Context, p.Args)
+			if err != nil {
+				return nil, err
+			}
+			return session.ImportAuthorizedZones()
+		},
+	})
+}
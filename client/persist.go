@@ -0,0 +1,157 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package client provides helpers for frontends consuming the engine API,
+// so each CLI does not have to reimplement durable result handling.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// Store receives each streamed message and persists it locally.
+type Store interface {
+	// Write persists one result; seq is the message's position in the stream
+	// and doubles as the resume token.
+	Write(seq int, msg json.RawMessage) error
+	// Resume returns the sequence number to continue from after a restart.
+	Resume() (int, error)
+	Close() error
+}
+
+// JSONLStore persists streamed results as line-delimited JSON with a
+// sidecar file holding the resume token.
+type JSONLStore struct {
+	file   *os.File
+	resume string
+	seq    int
+}
+
+// NewJSONLStore opens or creates the store rooted at the provided path.
+func NewJSONLStore(path string) (*JSONLStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &JSONLStore{
+		file:   file,
+		resume: path + ".resume",
+	}
+	if seq, err := s.Resume(); err == nil {
+		s.seq = seq
+	}
+	return s, nil
+}
+
+// Write implements the Store interface.
+func (s *JSONLStore) Write(seq int, msg json.RawMessage) error {
+	if _, err := s.file.Write(append(msg, '\n')); err != nil {
+		return err
+	}
+	s.seq = seq
+	return os.WriteFile(s.resume, []byte(itoa(seq)), 0644)
+}
+
+// Resume implements the Store interface.
+func (s *JSONLStore) Resume() (int, error) {
+	data, err := os.ReadFile(s.resume)
+	if err != nil {
+		return 0, err
+	}
+
+	var seq int
+	if err := json.Unmarshal(data, &seq); err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// Close implements the Store interface.
+func (s *JSONLStore) Close() error {
+	return s.file.Close()
+}
+
+func itoa(n int) string {
+	data, _ := json.Marshal(n)
+	return string(data)
+}
+
+// Persist connects to the engine's subscription endpoint and copies every
+// finding into the store until the context is canceled or the session ends.
+// After a disconnect, it resumes from the store's token so the local copy
+// stays complete without duplicates.
+func Persist(ctx context.Context, endpoint, sessionToken string, store Store) error {
+	for {
+		err := persistOnce(ctx, endpoint, sessionToken, store)
+		if err == nil || errors.Is(err, context.Canceled) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+func persistOnce(ctx context.Context, endpoint, sessionToken string, store Store) error {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx,
+		endpoint+"?sessionToken="+sessionToken, nil)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	seq, _ := store.Resume()
+
+	var count int
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		var msg struct {
+			Type   string `json:"type"`
+			Active bool   `json:"active"`
+		}
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+
+		switch msg.Type {
+		case "finding":
+			count++
+			if count <= seq {
+				continue
+			}
+			if err := store.Write(count, data); err != nil {
+				return err
+			}
+		case "heartbeat":
+			if !msg.Active {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+// The engine command is a reference frontend for the enumeration engine:
+// it starts the API, creates a session from a configuration file, streams
+// results to stdout or a JSONL file, and reports live statistics. It also
+// serves as an end-to-end smoke test of the API surface.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	assetdb "github.com/owasp-amass/asset-db"
+	"github.com/owasp-amass/asset-db/repository"
+	"github.com/owasp-amass/config/config"
+	"github.com/owasp-amass/engine/api/audit"
+	"github.com/owasp-amass/engine/api/auth"
+	"github.com/owasp-amass/engine/api/dashboard"
+	"github.com/owasp-amass/engine/api/graphql"
+	"github.com/owasp-amass/engine/dispatcher"
+	"github.com/owasp-amass/engine/federation"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/registry"
+	"github.com/owasp-amass/engine/sessions"
+	"github.com/owasp-amass/engine/types"
+
+	"github.com/owasp-amass/engine/plugins/alterations"
+	pluginsapi "github.com/owasp-amass/engine/plugins/api"
+	pluginsdns "github.com/owasp-amass/engine/plugins/dns"
+	"github.com/owasp-amass/engine/plugins/email"
+	"github.com/owasp-amass/engine/plugins/scrape"
+	"github.com/owasp-amass/engine/plugins/web"
+)
+
+func main() {
+	var opts engineOptions
+
+	// Environment variables provide the defaults so the same binary works
+	// as a container entrypoint with no command line at all.
+	flag.StringVar(&opts.ConfigFile, "config", os.Getenv("AMASS_CONFIG"), "path to the session configuration file")
+	flag.StringVar(&opts.Addr, "addr", envDefault("AMASS_API_ADDR", "127.0.0.1:4000"), "address for the API to listen on")
+	flag.StringVar(&opts.PIDFile, "pidfile", os.Getenv("AMASS_PID_FILE"), "write the process ID to this file")
+	flag.StringVar(&opts.SeedFile, "seeds", "", "seed the session with the names in this file as well")
+	flag.StringVar(&opts.AnalyzeDB, "analyze", "", "attach a read-only analysis session to this existing SQLite database")
+	flag.StringVar(&opts.ReplayFile, "replay", "", "re-dispatch the events recorded in this journal into the session")
+	flag.StringVar(&opts.Profile, "profile", "",
+		"configuration profile for the session ("+strings.Join(sessions.ProfileNames(), ", ")+")")
+	flag.StringVar(&opts.JSONLFile, "jsonl", "", "write results to this JSONL file instead of stdout")
+	flag.DurationVar(&opts.StatsInterval, "stats", 30*time.Second, "interval between live stat reports (0 disables)")
+	flag.Parse()
+
+	log := slog.New(slog.NewTextHandler(os.Stderr, nil))
+
+	if err := opts.validate(); err != nil {
+		log.Error("invalid engine options", "err", err)
+		os.Exit(1)
+	}
+
+	// Arm the kill switch before any plugin work can begin.
+	support.WatchStopSignal()
+
+	reg := registry.NewRegistry(log)
+	plugins := enginePlugins()
+	for _, p := range plugins {
+		if err := p.Start(reg); err != nil {
+			log.Error("failed to start a plugin", "plugin", p.Name(), "err", err)
+			os.Exit(1)
+		}
+		defer p.Stop()
+	}
+	d := dispatcher.NewDispatcher(log, reg)
+
+	manager := sessions.NewManager(log)
+	defer manager.Shutdown()
+
+	// The configuration is loaded before the API server starts, since it
+	// also carries the server's audit and authentication settings.
+	var cfg *config.Config
+	if opts.ConfigFile != "" {
+		cfg = config.NewConfig()
+		if err := config.AcquireConfig("", opts.ConfigFile, cfg); err != nil {
+			log.Error("failed to load the configuration file", "err", err)
+			os.Exit(1)
+		}
+		if opts.Profile != "" {
+			if err := sessions.ApplyProfile(cfg, opts.Profile); err != nil {
+				log.Error("failed to apply the configuration profile", "err", err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	server, err := graphql.NewServer(manager)
+	if err != nil {
+		log.Error("failed to build the API server", "err", err)
+		os.Exit(1)
+	}
+	server.SetDispatcher(d)
+
+	if path := support.OptionString(cfg, "audit/log", ""); path != "" {
+		alog, err := audit.NewLogger(path, support.OptionEnabled(cfg, "audit/syslog"))
+		if err != nil {
+			log.Error("failed to open the audit log", "path", path, "err", err)
+			os.Exit(1)
+		}
+		defer alog.Close()
+		server.SetAuditLogger(alog)
+	}
+
+	removePID, err := writePIDFile(opts.PIDFile)
+	if err != nil {
+		log.Error("failed to write the PID file", "err", err)
+		os.Exit(1)
+	}
+	defer removePID()
+
+	listener, err := activationListener(opts.Addr)
+	if err != nil {
+		log.Error("failed to create the API listener", "err", err)
+		os.Exit(1)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/graphql", server)
+	mux.HandleFunc("/graphql/schema", server.SchemaHandler)
+	mux.HandleFunc("/subscribe", server.SubscriptionHandler)
+	mux.HandleFunc("/events", server.SSEHandler)
+	mux.Handle("/dashboard/", http.StripPrefix("/dashboard", dashboard.NewHandler(manager)))
+
+	var handler http.Handler = mux
+	if issuer := support.OptionString(cfg, "oidc/issuer", ""); issuer != "" {
+		middleware, err := auth.NewOIDCMiddleware(context.Background(), auth.OIDCConfig{
+			Issuer:      issuer,
+			Audience:    support.OptionString(cfg, "oidc/audience", ""),
+			TenantClaim: support.OptionString(cfg, "oidc/tenant_claim", ""),
+		})
+		if err != nil {
+			log.Error("failed to discover the OIDC provider", "issuer", issuer, "err", err)
+			os.Exit(1)
+		}
+		handler = middleware.Wrap(mux)
+	}
+
+	go func() {
+		if err := http.Serve(listener, handler); err != nil {
+			log.Error("the API server terminated", "err", err)
+			os.Exit(1)
+		}
+	}()
+	log.Info("the engine API is listening", "addr", listener.Addr().String())
+
+	reloadOnHUP(func() {
+		if opts.ConfigFile == "" {
+			return
+		}
+		cfg := config.NewConfig()
+		if err := config.AcquireConfig("", opts.ConfigFile, cfg); err != nil {
+			log.Error("failed to reload the configuration file", "err", err)
+			return
+		}
+		for _, session := range manager.AllSessions() {
+			session.Reconfigure(cfg.Options, nil, nil)
+		}
+		log.Info("configuration reloaded")
+	})
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+
+	if cfg == nil {
+		<-quit
+		return
+	}
+
+	reg.ConfigurePipelines(cfg)
+	support.SetDNSBatchSize(support.OptionInt(cfg, "dns/batch_size", 0))
+	support.SetDNSMaxQPS(support.OptionInt(cfg, "dns/max_qps", 0))
+	support.SetHTTPMaxConcurrency(support.OptionInt(cfg, "http/max_concurrency", 0))
+	support.SetComplianceMode(support.OptionEnabled(cfg, "compliance/enabled"),
+		support.OptionString(cfg, "compliance/user_agent", ""),
+		support.OptionString(cfg, "compliance/contact_url", ""))
+
+	var session *sessions.Session
+	if opts.AnalyzeDB != "" {
+		session, err = sessions.NewAnalysisSession(cfg, assetdb.New(repository.SQLite, opts.AnalyzeDB))
+	} else {
+		session, err = sessions.NewSession(cfg, nil, nil)
+	}
+	if err != nil {
+		log.Error("failed to create the session", "err", err)
+		os.Exit(1)
+	}
+	if err := manager.AddSession(session); err != nil {
+		log.Error("failed to register the session", "err", err)
+		os.Exit(1)
+	}
+	defer session.Kill()
+	log.Info("session created", "token", session.ID().String())
+
+	if !session.ReadOnly() {
+		go func() {
+			session.SeedScope(d)
+			if opts.SeedFile != "" {
+				if err := session.SeedFile(d, opts.SeedFile); err != nil {
+					log.Error("failed to stream the seed file", "path", opts.SeedFile, "err", err)
+				}
+			}
+			if opts.ReplayFile != "" {
+				if err := support.ReplayJournal(session, d, opts.ReplayFile); err != nil {
+					log.Error("failed to replay the journal", "path", opts.ReplayFile, "err", err)
+				}
+			}
+		}()
+	}
+
+	out := os.Stdout
+	if opts.JSONLFile != "" {
+		f, err := os.OpenFile(opts.JSONLFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			log.Error("failed to open the output file", "err", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	t := time.NewTicker(2 * time.Second)
+	defer t.Stop()
+	var stats <-chan time.Time
+	if opts.StatsInterval > 0 {
+		st := time.NewTicker(opts.StatsInterval)
+		defer st.Stop()
+		stats = st.C
+	}
+
+	enc := json.NewEncoder(out)
+	var written int
+	for {
+		select {
+		case <-quit:
+			return
+		case <-stats:
+			printStats(session, plugins)
+			for atype, p := range reg.PipelineUtilization() {
+				fmt.Fprintf(os.Stderr, "pipeline %s: %d/%d active, %d processed\n",
+					atype, p.Active, p.Workers, p.Processed)
+			}
+		case <-t.C:
+			findings := support.SessionFindings(session.ID())
+			for ; written < len(findings); written++ {
+				_ = enc.Encode(findings[written])
+			}
+			if session.Done() {
+				// Remote findings are merged first so they feed the
+				// snapshot the delta is computed from.
+				if c := federation.NewClient(cfg); c != nil && support.FeatureEnabled(cfg, "cluster-mode") {
+					if err := c.MergeResults(session); err != nil {
+						log.Error("failed to merge the federated results", "err", err)
+					}
+				}
+				if _, err := session.ComputeDelta(); err != nil {
+					log.Error("failed to compute the delta against the previous run", "err", err)
+				}
+				return
+			}
+		}
+	}
+}
+
+// envDefault returns the environment variable's value when set, and the
+// provided fallback otherwise.
+func envDefault(name, fallback string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// enginePlugins returns every plugin shipped with the engine.
+func enginePlugins() []types.Plugin {
+	return []types.Plugin{
+		alterations.NewAltLearner(),
+		alterations.NewHomographs(),
+		pluginsapi.NewChaos(),
+		pluginsapi.NewCTWatch(),
+		pluginsapi.NewCIRCLPDNS(),
+		pluginsapi.NewCIRCLPSSL(),
+		pluginsapi.NewDNSDB(),
+		pluginsapi.NewGoogleCSE(),
+		pluginsapi.NewGrepApp(),
+		pluginsapi.NewHunterIO(),
+		pluginsapi.NewLeakIX(),
+		pluginsapi.NewMISP(),
+		pluginsapi.NewMnemonic(),
+		pluginsapi.NewReverseWhois(),
+		pluginsdns.NewCNAME(),
+		pluginsdns.NewDNAME(),
+		pluginsdns.NewEmailPosture(),
+		pluginsdns.NewIDN(),
+		pluginsdns.NewMX(),
+		pluginsdns.NewPosture(),
+		pluginsdns.NewProvider(),
+		pluginsdns.NewSPF(),
+		pluginsdns.NewTXT(),
+		pluginsdns.NewWildcard(),
+		email.NewSMTPValidate(),
+		scrape.NewBing(),
+		scrape.NewDuckDuckGo(),
+		scrape.NewRapidDNS(),
+		scrape.NewSiteDossier(),
+		scrape.NewDNSHistory(),
+		web.NewBannerGrab(),
+		web.NewCertGraph(),
+		web.NewDocMeta(),
+		web.NewJARM(),
+		web.NewJSAnalysis(),
+		web.NewProbe(),
+		web.NewRobots(),
+		web.NewScreenshots(),
+		web.NewURLPipeline(),
+	}
+}
+
+// printStats writes the per-plugin counters for the session to stderr.
+func printStats(s *sessions.Session, plugins []types.Plugin) {
+	for _, p := range plugins {
+		for name, value := range support.PluginStats(s.ID(), p.Name()) {
+			fmt.Fprintf(os.Stderr, "stat %s/%s: %d\n", p.Name(), name, value)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"time"
+)
+
+// engineOptions gathers every startup setting in one validated struct, so
+// a misconfigured flag or environment variable fails at startup with an
+// actionable message instead of surfacing later as odd behavior.
+type engineOptions struct {
+	// ConfigFile is the session configuration to load; empty runs the
+	// engine API without creating a session.
+	ConfigFile string
+	// Addr is the address the API listens on.
+	Addr string
+	// JSONLFile receives results instead of stdout when set.
+	JSONLFile string
+	// PIDFile receives the process ID when set.
+	PIDFile string
+	// SeedFile is an additional newline-delimited list of names to seed
+	// the session with, beyond the configured scope.
+	SeedFile string
+	// Profile names the configuration profile applied to the session;
+	// empty leaves the configured options untouched.
+	Profile string
+	// AnalyzeDB attaches a read-only analysis session to this existing
+	// SQLite database instead of running an enumeration.
+	AnalyzeDB string
+	// ReplayFile is an event journal from a previous run re-dispatched
+	// into the session for a deterministic replay.
+	ReplayFile string
+	// StatsInterval spaces the live stat reports; zero disables them.
+	StatsInterval time.Duration
+}
+
+// validate reports the first misconfiguration with an actionable message.
+func (o *engineOptions) validate() error {
+	if o.ConfigFile != "" {
+		if _, err := os.Stat(o.ConfigFile); err != nil {
+			return fmt.Errorf("the configuration file %s cannot be read: %v", o.ConfigFile, err)
+		}
+	}
+	if o.AnalyzeDB != "" {
+		if _, err := os.Stat(o.AnalyzeDB); err != nil {
+			return fmt.Errorf("the analysis database %s cannot be read: %v", o.AnalyzeDB, err)
+		}
+	}
+	if o.SeedFile != "" {
+		if _, err := os.Stat(o.SeedFile); err != nil {
+			return fmt.Errorf("the seed file %s cannot be read: %v", o.SeedFile, err)
+		}
+	}
+	if o.ReplayFile != "" {
+		if _, err := os.Stat(o.ReplayFile); err != nil {
+			return fmt.Errorf("the replay journal %s cannot be read: %v", o.ReplayFile, err)
+		}
+	}
+	if _, _, err := net.SplitHostPort(o.Addr); err != nil {
+		return fmt.Errorf("the API address %q is not a host:port pair: %v", o.Addr, err)
+	}
+	if o.StatsInterval < 0 {
+		return fmt.Errorf("the stats interval %s is negative; use 0 to disable stat reports", o.StatsInterval)
+	}
+	if o.StatsInterval > 0 && o.StatsInterval < time.Second {
+		return fmt.Errorf("the stats interval %s is below one second and would flood stderr", o.StatsInterval)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+)
+
+// listenFdsStart is the first file descriptor number systemd uses to pass
+// activated sockets to a service.
+const listenFdsStart = 3
+
+// activationListener returns the listener passed by systemd socket
+// activation when the process was started that way, and otherwise listens
+// on the provided address itself.
+func activationListener(addr string) (net.Listener, error) {
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return net.Listen("tcp", addr)
+	}
+
+	nfds, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || nfds < 1 {
+		return net.Listen("tcp", addr)
+	}
+	// The variables are cleared so they do not leak into child processes.
+	_ = os.Unsetenv("LISTEN_PID")
+	_ = os.Unsetenv("LISTEN_FDS")
+
+	f := os.NewFile(uintptr(listenFdsStart), "systemd-activation")
+	defer f.Close()
+
+	l, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to use the activated socket: %v", err)
+	}
+	return l, nil
+}
+
+// writePIDFile records the process ID at the provided path and returns a
+// cleanup function removing it on shutdown.
+func writePIDFile(path string) (func(), error) {
+	if path == "" {
+		return func() {}, nil
+	}
+
+	data := []byte(strconv.Itoa(os.Getpid()) + "\n")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, err
+	}
+	return func() { _ = os.Remove(path) }, nil
+}
+
+// reloadOnHUP invokes the callback each time the process receives SIGHUP,
+// allowing the daemon to reload its configuration without restarting.
+func reloadOnHUP(reload func()) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	go func() {
+		for range hup {
+			reload()
+		}
+	}()
+}
@@ -0,0 +1,104 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dispatcher
+
+import (
+	"sync"
+	"time"
+
+	"github.com/owasp-amass/config/config"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+)
+
+// Budget options. The per-handler keys, such as
+// "budget/DNS-CNAME-Handler/max_ms", override the global ones.
+const (
+	budgetWallOption     = "budget/max_ms"
+	budgetRequestsOption = "budget/max_requests"
+	// budgetStrikes is how many violations suspend a handler for the rest
+	// of the process lifetime.
+	budgetStrikes = 3
+)
+
+var strikeLock sync.Mutex
+var strikes = make(map[string]int)
+
+// runWithBudget executes one handler callback under the configured wall
+// time and outbound request budgets, so a single pathological asset cannot
+// stall its pipeline stage indefinitely. A handler collecting repeated
+// violations is suspended.
+func (d *Dispatcher) runWithBudget(e *types.Event, h *types.Handler) error {
+	if suspended(h.Name) {
+		return nil
+	}
+
+	cfg := e.Session.Config()
+	wall := budgetOption(cfg, h.Name, "max_ms", budgetWallOption)
+	maxReq := budgetOption(cfg, h.Name, "max_requests", budgetRequestsOption)
+
+	if wall <= 0 && maxReq <= 0 {
+		return h.Callback(e)
+	}
+
+	// The outbound counter is engine-wide, so with concurrent dispatches
+	// the attribution is approximate; only repeated violations count.
+	before := support.OutboundRequests()
+
+	done := make(chan error, 1)
+	go func() { done <- h.Callback(e) }()
+
+	var timeout <-chan time.Time
+	if wall > 0 {
+		t := time.NewTimer(time.Duration(wall) * time.Millisecond)
+		defer t.Stop()
+		timeout = t.C
+	}
+
+	select {
+	case err := <-done:
+		if maxReq > 0 && support.OutboundRequests()-before > int64(maxReq) {
+			d.strike(e, h, "outbound request budget exceeded")
+		}
+		return err
+	case <-timeout:
+		// The callback keeps running on its goroutine, but the pipeline
+		// moves on instead of waiting for it. The event must outlive the
+		// straggler, so it is withdrawn from pool recycling.
+		types.DisownEvent(e)
+		d.strike(e, h, "wall time budget exceeded")
+		return nil
+	}
+}
+
+// strike records one budget violation against the handler.
+func (d *Dispatcher) strike(e *types.Event, h *types.Handler, reason string) {
+	strikeLock.Lock()
+	strikes[h.Name]++
+	count := strikes[h.Name]
+	strikeLock.Unlock()
+
+	d.log.Warn("a handler exceeded its execution budget",
+		"handler", h.Name, "event", e.Name, "reason", reason, "strikes", count)
+
+	if count == budgetStrikes {
+		d.log.Warn("the handler has been suspended for repeated budget violations",
+			"handler", h.Name)
+	}
+}
+
+// suspended reports whether the handler collected too many strikes.
+func suspended(name string) bool {
+	strikeLock.Lock()
+	defer strikeLock.Unlock()
+	return strikes[name] >= budgetStrikes
+}
+
+// budgetOption reads the handler-specific budget value, falling back to
+// the global key.
+func budgetOption(cfg *config.Config, handler, suffix, global string) int {
+	return support.OptionInt(cfg, "budget/"+handler+"/"+suffix,
+		support.OptionInt(cfg, global, 0))
+}
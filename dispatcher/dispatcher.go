@@ -0,0 +1,202 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dispatcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/federation"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/registry"
+	"github.com/owasp-amass/engine/scheduler"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+)
+
+// suppressionWindowOption sets, in minutes, how long a dispatched FQDN
+// suppresses duplicates before it may be submitted again.
+const suppressionWindowOption = "dispatch/suppression_window"
+
+// Dispatcher routes events to the handlers registered for their asset type.
+// Handler execution is queue-based: DispatchEvent only validates and
+// schedules, and the scheduler's worker pool performs the deliveries, so a
+// handler submitting follow-on work never waits on its own pipeline slot.
+type Dispatcher struct {
+	log   *slog.Logger
+	reg   *registry.Registry
+	sched *scheduler.Scheduler
+}
+
+// NewDispatcher returns a dispatcher routing through the provided registry.
+func NewDispatcher(log *slog.Logger, reg *registry.Registry) *Dispatcher {
+	if log == nil {
+		log = slog.Default()
+	}
+
+	d := &Dispatcher{
+		log:   log,
+		reg:   reg,
+		sched: scheduler.NewScheduler(log),
+	}
+	go d.sched.Process(&scheduler.ProcessConfig{Workers: support.MaxHandlerInstances})
+	return d
+}
+
+// DispatchEvent implements the types.Dispatcher interface.
+func (d *Dispatcher) DispatchEvent(e *types.Event) error {
+	if e == nil || e.Asset == nil || e.Session == nil {
+		return errors.New("the event is not valid for dispatch")
+	}
+	if e.Session.Done() {
+		return errors.New("the session has been terminated")
+	}
+	if e.Session.ReadOnly() {
+		return errors.New("the session is a read-only analysis session")
+	}
+	if e.Dispatcher == nil {
+		e.Dispatcher = d
+	}
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	normalizeEvent(e)
+	support.JournalEvent(e)
+	support.ObserveAsset(e.Session.ID(), e.Name)
+
+	// Correlated events are counted in and out of dispatch so the client
+	// can be notified once the subtree rooted at their seed completes. The
+	// out-count moves to the delivery once the event is handed to the
+	// scheduler. The session and correlation ID are captured in locals
+	// because the drop paths below release the event, zeroing its fields
+	// before the deferred closure runs.
+	var handedOff bool
+	if cid, session := e.Correlation, e.Session; cid != "" {
+		support.CorrelationStarted(session.ID(), cid, e.Name)
+		defer func() {
+			if !handedOff {
+				support.CorrelationFinished(session, cid)
+			}
+		}()
+	}
+
+	if err := support.MaybeInjectFault(e.Session.Config(), "dispatch"); err != nil {
+		types.ReleaseEvent(e)
+		if errors.Is(err, support.ErrFaultDropped) {
+			return nil
+		}
+		return err
+	}
+
+	atype := e.Asset.Asset.AssetType()
+	if atype == oam.FQDN {
+		// First sight of a name passes through and is remembered; later
+		// duplicates are dropped before reaching the handlers. With a
+		// suppression window configured the memory expires, so long
+		// sessions can re-validate assets without clearing the cache.
+		if m := support.OptionInt(e.Session.Config(), suppressionWindowOption, 0); m > 0 {
+			support.ConfigureSuppression(e.Session.ID(), time.Duration(m)*time.Minute)
+		}
+		if support.KnownFQDN(e.Session.ID(), e.Name) {
+			types.ReleaseEvent(e)
+			return nil
+		}
+		support.RememberFQDN(e.Session.ID(), e.Name)
+	}
+
+	handlers := d.reg.GetHandlers(atype)
+	if len(handlers) == 0 {
+		// Asset types with no local handlers can be forwarded to a
+		// federated remote engine when one is configured and the
+		// cluster-mode feature has not been switched off.
+		if c := federation.NewClient(e.Session.Config()); c != nil &&
+			support.FeatureEnabled(e.Session.Config(), "cluster-mode") {
+			if err := c.Forward(e); err != nil {
+				d.log.Debug("failed to forward the event", "event", e.Name, "err", err)
+			}
+		}
+		types.ReleaseEvent(e)
+		return nil
+	}
+
+	if err := d.sched.Schedule(&scheduler.Event{
+		ID: e.ID,
+		Action: func() error {
+			d.deliver(e, handlers, atype)
+			return nil
+		},
+	}); err != nil {
+		types.ReleaseEvent(e)
+		return err
+	}
+	handedOff = true
+	return nil
+}
+
+// deliver runs the registered handlers against the event from a scheduler
+// worker, holding a pipeline slot for its asset type while they execute.
+func (d *Dispatcher) deliver(e *types.Event, handlers []*types.Handler, atype oam.AssetType) {
+	if cid := e.Correlation; cid != "" {
+		defer support.CorrelationFinished(e.Session, cid)
+	}
+
+	release := d.reg.AcquireSlot(atype)
+	defer release()
+
+	for _, h := range handlers {
+		if !d.gateAllows(e, h) {
+			continue
+		}
+		// Every log line a handler emits through the event is correlated
+		// without the plugin constructing the groups itself.
+		e.SetLogger(e.Session.Log().With(
+			"session", e.Session.ID().String(),
+			"event", e.ID.String(),
+			"asset", e.Name,
+			"plugin", h.Plugin.Name()))
+		if support.CaptureEnabled(e, h.Plugin.Name()) {
+			state, _ := json.Marshal(map[string]string{
+				"asset": e.Name,
+				"type":  string(atype),
+				"prov":  fmt.Sprintf("%v", support.AssetProvenance(e.Session.ID(), e.Name)),
+			})
+			support.CaptureDebug(e, h.Plugin.Name(), "pre-handler", state)
+		}
+		if err := d.runWithBudget(e, h); err != nil {
+			d.log.Debug("handler returned an error",
+				"handler", h.Name, "event", e.Name, "err", err)
+		}
+	}
+
+	// Pooled events go back for reuse once the last handler has run.
+	types.ReleaseEvent(e)
+}
+
+// gateAllows enforces confidence-weighted transform gating: a transform
+// rule can require a minimum provenance class or confidence before its
+// handlers run against an asset, so active stages like port scanning only
+// touch assets with trustworthy origins.
+func (d *Dispatcher) gateAllows(e *types.Event, h *types.Handler) bool {
+	cfg := e.Session.Config()
+
+	prov := support.AssetProvenance(e.Session.ID(), e.Name)
+	for _, transform := range h.Transforms {
+		if class := support.OptionString(cfg, "transform/"+transform+"/min_provenance", ""); class != "" {
+			if prov == nil || support.ProvenanceRank(prov.Class) < support.ProvenanceRank(class) {
+				return false
+			}
+		}
+		if min := support.OptionInt(cfg, "transform/"+transform+"/min_confidence", 0); min > 0 {
+			if prov == nil || prov.Confidence < min {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,196 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dispatcher_test
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	assetdb "github.com/owasp-amass/asset-db"
+	dbt "github.com/owasp-amass/asset-db/types"
+	"github.com/owasp-amass/config/config"
+	"github.com/owasp-amass/engine/dispatcher"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/registry"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// testSession is the minimal types.Session used to exercise the dispatcher
+// without storage.
+type testSession struct {
+	id       uuid.UUID
+	cfg      *config.Config
+	readOnly bool
+}
+
+func newTestSession() *testSession {
+	return &testSession{id: uuid.New(), cfg: config.NewConfig()}
+}
+
+func (s *testSession) ID() uuid.UUID           { return s.id }
+func (s *testSession) Log() *slog.Logger       { return slog.Default() }
+func (s *testSession) Config() *config.Config  { return s.cfg }
+func (s *testSession) DB() *assetdb.AssetDB    { return nil }
+func (s *testSession) Cache() *assetdb.AssetDB { return nil }
+func (s *testSession) ReadOnly() bool          { return s.readOnly }
+func (s *testSession) Done() bool              { return false }
+func (s *testSession) Kill()                   {}
+
+// testPlugin counts deliveries and runs the provided callback.
+type testPlugin struct {
+	name     string
+	callback func(*types.Event) error
+}
+
+func (p *testPlugin) Name() string { return p.name }
+func (p *testPlugin) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:    p,
+		Name:      p.name + "-Handler",
+		EventType: oam.FQDN,
+		Callback:  p.callback,
+	})
+}
+func (p *testPlugin) Stop() {}
+
+func fqdnEvent(s types.Session, d types.Dispatcher, name string) *types.Event {
+	e := types.AcquireEvent()
+	e.Name = name
+	e.Asset = &dbt.Asset{Asset: &oamdns.FQDN{Name: name}}
+	e.Timestamp = time.Now()
+	e.Session = s
+	e.Dispatcher = d
+	return e
+}
+
+func waitForCount(t *testing.T, counter *atomic.Int64, want int64) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if counter.Load() >= want {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d deliveries, saw %d", want, counter.Load())
+}
+
+// TestNestedDispatchAtWorkerCap floods the dispatcher with more events than
+// it has workers, each handler submitting follow-on work, and requires every
+// delivery to complete. Synchronous delivery would deadlock here: handlers
+// holding every worker would block submitting events no free worker can take.
+func TestNestedDispatchAtWorkerCap(t *testing.T) {
+	var count atomic.Int64
+	plugin := &testPlugin{
+		name: "nested-dispatch",
+		callback: func(e *types.Event) error {
+			count.Add(1)
+			if strings.Count(e.Name, ".") < 4 {
+				return e.Dispatcher.DispatchEvent(fqdnEvent(e.Session, e.Dispatcher, "x."+e.Name))
+			}
+			return nil
+		},
+	}
+
+	reg := registry.NewRegistry(nil)
+	if err := plugin.Start(reg); err != nil {
+		t.Fatalf("failed to start the plugin: %v", err)
+	}
+
+	s := newTestSession()
+	defer support.CleanupSession(s.ID())
+	d := dispatcher.NewDispatcher(nil, reg)
+
+	roots := 2 * support.MaxHandlerInstances
+	for i := 0; i < roots; i++ {
+		name := fmt.Sprintf("n%d.example.com", i)
+		if err := d.DispatchEvent(fqdnEvent(s, d, name)); err != nil {
+			t.Fatalf("failed to dispatch %s: %v", name, err)
+		}
+	}
+
+	// Each root produces two generations of follow-on events.
+	waitForCount(t, &count, int64(3*roots))
+}
+
+func TestDispatchSuppressesDuplicates(t *testing.T) {
+	var count atomic.Int64
+	plugin := &testPlugin{
+		name:     "duplicate-count",
+		callback: func(e *types.Event) error { count.Add(1); return nil },
+	}
+
+	reg := registry.NewRegistry(nil)
+	if err := plugin.Start(reg); err != nil {
+		t.Fatalf("failed to start the plugin: %v", err)
+	}
+
+	s := newTestSession()
+	defer support.CleanupSession(s.ID())
+	d := dispatcher.NewDispatcher(nil, reg)
+
+	for i := 0; i < 3; i++ {
+		if err := d.DispatchEvent(fqdnEvent(s, d, "www.example.com")); err != nil {
+			t.Fatalf("failed to dispatch the event: %v", err)
+		}
+	}
+
+	waitForCount(t, &count, 1)
+	time.Sleep(100 * time.Millisecond)
+	if got := count.Load(); got != 1 {
+		t.Errorf("expected the duplicates dropped, saw %d deliveries", got)
+	}
+}
+
+// TestCorrelationCompletesOnDroppedEvent covers the drop paths: an event
+// carrying a correlation ID that never reaches the handlers must still be
+// counted out of dispatch, or the completion notification never fires.
+func TestCorrelationCompletesOnDroppedEvent(t *testing.T) {
+	reg := registry.NewRegistry(nil)
+	s := newTestSession()
+	defer support.CleanupSession(s.ID())
+	d := dispatcher.NewDispatcher(nil, reg)
+
+	// Already-known names are dropped before scheduling, releasing the event.
+	support.RememberFQDN(s.ID(), "www.example.com")
+
+	e := fqdnEvent(s, d, "www.example.com")
+	e.Correlation = "corr-drop"
+	if err := d.DispatchEvent(e); err != nil {
+		t.Fatalf("failed to dispatch the event: %v", err)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, f := range support.SessionFindings(s.ID()) {
+			if f.Type == "correlation:complete" {
+				return
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("the correlation never reported completion")
+}
+
+func TestDispatchRejectsReadOnlySessions(t *testing.T) {
+	reg := registry.NewRegistry(nil)
+	d := dispatcher.NewDispatcher(nil, reg)
+
+	s := newTestSession()
+	s.readOnly = true
+	defer support.CleanupSession(s.ID())
+
+	if err := d.DispatchEvent(fqdnEvent(s, d, "www.example.com")); err == nil {
+		t.Error("expected dispatch into a read-only session to fail")
+	}
+}
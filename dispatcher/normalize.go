@@ -0,0 +1,50 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dispatcher
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	oamnet "github.com/owasp-amass/open-asset-model/network"
+
+	"golang.org/x/net/idna"
+)
+
+// normalizeEvent applies the engine's single normalization policy before
+// any handler or store sees the event: hostnames are lowercased, trimmed,
+// and converted to their IDN A-label form; addresses take their canonical
+// textual representation. Enforcing this here means the cache and graph
+// never hold duplicate assets differing only in representation.
+func normalizeEvent(e *types.Event) {
+	switch asset := e.Asset.Asset.(type) {
+	case *oamdns.FQDN:
+		name := normalizeName(e.Session.ID(), asset.Name)
+		asset.Name = name
+		e.Name = name
+	case *oamnet.IPAddress:
+		if addr, err := netip.ParseAddr(asset.Address.String()); err == nil {
+			asset.Address = addr
+			e.Name = addr.String()
+		}
+	default:
+		e.Name = strings.ToLower(strings.TrimSpace(e.Name))
+	}
+}
+
+// normalizeName lowercases the hostname, strips stray dots and whitespace,
+// and converts unicode labels to their punycode A-label form.
+func normalizeName(id uuid.UUID, name string) string {
+	name = strings.ToLower(strings.Trim(strings.TrimSpace(name), "."))
+
+	if ascii, err := idna.Lookup.ToASCII(name); err == nil {
+		name = ascii
+	}
+	return support.InternFQDN(id, name)
+}
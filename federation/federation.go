@@ -0,0 +1,146 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package federation lets a local engine forward work it will not perform
+// itself to a remote engine's API and merge the returned results, enabling
+// split deployments such as passive collection locally with active stages
+// running elsewhere.
+package federation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/owasp-amass/config/config"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+)
+
+// Options naming the remote engine. With no endpoint configured,
+// federation stays disabled and forwarding is a no-op.
+const (
+	EndpointOption = "federation/endpoint"
+	TokenOption    = "federation/token"
+)
+
+// Client talks to one remote engine's API.
+type Client struct {
+	endpoint string
+	token    string
+	http     *http.Client
+}
+
+// NewClient returns a federation client for the session's configuration,
+// or nil when federation is not configured.
+func NewClient(cfg *config.Config) *Client {
+	endpoint := support.OptionString(cfg, EndpointOption, "")
+	if endpoint == "" {
+		return nil
+	}
+	return &Client{
+		endpoint: endpoint,
+		token:    support.OptionString(cfg, TokenOption, ""),
+		http:     &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// graphqlRequest posts one GraphQL operation to the remote engine.
+func (c *Client) graphqlRequest(ctx context.Context, query string, variables map[string]interface{}) (json.RawMessage, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 {
+		return nil, errors.New("the remote engine returned: " + result.Errors[0].Message)
+	}
+	return result.Data, nil
+}
+
+// Forward submits the event's asset to the remote engine. It is called for
+// asset types the local engine has no handlers for, or is configured not
+// to run.
+func (c *Client) Forward(e *types.Event) error {
+	ctx, cancel := context.WithTimeout(support.EngineContext(), 30*time.Second)
+	defer cancel()
+
+	_, err := c.graphqlRequest(ctx, `mutation ($sessionToken: String!, $names: [String]!) {
+		submitAssets(sessionToken: $sessionToken, names: $names) { item ok code }
+	}`, map[string]interface{}{
+		"sessionToken": c.token,
+		"names":        []string{e.Name},
+	})
+	return err
+}
+
+// MergeResults pulls the remote session's findings and records them
+// locally, attributed to the remote engine.
+func (c *Client) MergeResults(session types.Session) error {
+	ctx, cancel := context.WithTimeout(support.EngineContext(), 30*time.Second)
+	defer cancel()
+
+	data, err := c.graphqlRequest(ctx, `query ($sessionToken: String!) {
+		sessionFindings(sessionToken: $sessionToken) {
+			type asset description severity source
+		}
+	}`, map[string]interface{}{
+		"sessionToken": c.token,
+	})
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		SessionFindings []struct {
+			Type        string `json:"type"`
+			Asset       string `json:"asset"`
+			Description string `json:"description"`
+			Severity    string `json:"severity"`
+			Source      string `json:"source"`
+		} `json:"sessionFindings"`
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return err
+	}
+
+	for _, f := range result.SessionFindings {
+		support.AddFinding(session, &support.Finding{
+			Type:        f.Type,
+			Asset:       f.Asset,
+			Description: f.Description,
+			Severity:    f.Severity,
+			Source:      "federated:" + c.endpoint,
+			Created:     time.Now(),
+		})
+	}
+	return nil
+}
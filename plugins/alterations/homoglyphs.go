@@ -0,0 +1,102 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package alterations
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// homographOption is the engine option that explicitly enables homograph
+// variant generation; the feature is off by default.
+const homographOption = "alterations/homographs"
+
+// confusables maps ASCII characters to the Unicode look-alikes most commonly
+// registered in homograph attacks.
+var confusables = map[rune][]rune{
+	'a': {'а', 'ạ'},
+	'c': {'с', 'ϲ'},
+	'e': {'е', 'ẹ'},
+	'i': {'і', 'ı'},
+	'l': {'ⅼ', 'ӏ'},
+	'o': {'о', 'ο'},
+	'p': {'р'},
+	's': {'ѕ'},
+	'x': {'х'},
+	'y': {'у'},
+}
+
+// homographs generates homograph-aware FQDN variants for in-scope names and
+// dispatches them as guesses, but only when explicitly enabled.
+type homographs struct {
+	name string
+}
+
+// NewHomographs returns the homograph variant generation plugin.
+func NewHomographs() types.Plugin {
+	return &homographs{name: "FQDN-Homographs"}
+}
+
+func (h *homographs) Name() string {
+	return h.name
+}
+
+func (h *homographs) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     h,
+		Name:       h.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   h.check,
+	})
+}
+
+func (h *homographs) Stop() {}
+
+func (h *homographs) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+	if !support.OptionEnabled(e.Session.Config(), homographOption) {
+		return nil
+	}
+
+	ulabel, err := support.ULabel(fqdn.Name)
+	if err != nil {
+		return nil
+	}
+	for _, variant := range h.variants(ulabel) {
+		if alabel, err := support.ALabel(variant); err == nil {
+			support.SubmitFQDNGuess(e, alabel)
+		}
+	}
+	return nil
+}
+
+// variants returns single-substitution homograph forms of the first label.
+func (h *homographs) variants(name string) []string {
+	labels := strings.SplitN(name, ".", 2)
+	if len(labels) != 2 {
+		return nil
+	}
+
+	var results []string
+	label := []rune(labels[0])
+	for i, r := range label {
+		for _, sub := range confusables[r] {
+			variant := make([]rune, len(label))
+			copy(variant, label)
+			variant[i] = sub
+			results = append(results, string(variant)+"."+labels[1])
+		}
+	}
+	return results
+}
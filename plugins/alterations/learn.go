@@ -0,0 +1,175 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package alterations
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// minPromotionCount is the number of times a token must be seen within a
+// session before it is promoted into the alteration vocabulary.
+const minPromotionCount int = 3
+
+// maxAlterationsPerEvent caps how many candidates one confirmed name may
+// generate, keeping a large promoted vocabulary from flooding the queue.
+const maxAlterationsPerEvent int = 100
+
+// altLearner mines labels and label-parts from names confirmed during the
+// session, promotes frequent tokens, and submits alteration guesses built
+// from them mid-run, adapting to organization-specific naming conventions.
+type altLearner struct {
+	name     string
+	mu       sync.Mutex
+	counts   map[uuid.UUID]map[string]int
+	promoted map[uuid.UUID]map[string]struct{}
+}
+
+// NewAltLearner returns the plugin that adapts alteration generation to
+// tokens observed in session results.
+func NewAltLearner() types.Plugin {
+	al := &altLearner{
+		name:     "FQDN-Alteration-Learning",
+		counts:   make(map[uuid.UUID]map[string]int),
+		promoted: make(map[uuid.UUID]map[string]struct{}),
+	}
+
+	support.OnSessionCleanup(func(id uuid.UUID) {
+		al.mu.Lock()
+		delete(al.counts, id)
+		delete(al.promoted, id)
+		al.mu.Unlock()
+	})
+	return al
+}
+
+func (al *altLearner) Name() string {
+	return al.name
+}
+
+func (al *altLearner) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     al,
+		Name:       al.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   al.check,
+	})
+}
+
+func (al *altLearner) Stop() {}
+
+func (al *altLearner) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	dom := e.Session.Config().WhichDomain(fqdn.Name)
+	if dom == "" || dom == fqdn.Name {
+		return nil
+	}
+
+	sub := strings.TrimSuffix(fqdn.Name, "."+dom)
+	al.mu.Lock()
+
+	id := e.Session.ID()
+	if _, found := al.counts[id]; !found {
+		al.counts[id] = make(map[string]int)
+		al.promoted[id] = make(map[string]struct{})
+	}
+
+	for _, label := range strings.Split(sub, ".") {
+		for _, token := range labelTokens(label) {
+			al.counts[id][token]++
+			if al.counts[id][token] >= minPromotionCount {
+				al.promote(e, id, token)
+			}
+		}
+	}
+	candidates := al.alterations(id, sub, dom)
+	al.mu.Unlock()
+
+	// The guesses are submitted outside the lock; scope, duplicate, and
+	// sampling checks all happen in the submission path.
+	for _, name := range candidates {
+		support.SubmitFQDNGuess(e, name)
+	}
+	return nil
+}
+
+// promote records the learned token exactly once per session.
+func (al *altLearner) promote(e *types.Event, id uuid.UUID, token string) {
+	if _, found := al.promoted[id][token]; found {
+		return
+	}
+	al.promoted[id][token] = struct{}{}
+	e.Logger().Info("promoted a token learned from session results", "token", token)
+}
+
+// alterations applies every promoted token to the confirmed name: the
+// first label is replaced by the token and decorated with it on both
+// sides. The caller must hold the lock.
+func (al *altLearner) alterations(id uuid.UUID, sub, dom string) []string {
+	first, rest, multi := strings.Cut(sub, ".")
+	suffix := dom
+	if multi {
+		suffix = rest + "." + dom
+	}
+
+	var results []string
+	for token := range al.promoted[id] {
+		if len(results) >= maxAlterationsPerEvent {
+			break
+		}
+		if token == first {
+			continue
+		}
+		results = append(results,
+			token+"."+suffix,
+			token+"-"+first+"."+suffix,
+			first+"-"+token+"."+suffix)
+	}
+	return results
+}
+
+// labelTokens splits a DNS label into the whole label plus parts broken on
+// hyphens and letter/digit boundaries.
+func labelTokens(label string) []string {
+	tokens := []string{label}
+
+	for _, part := range strings.Split(label, "-") {
+		if part != label && part != "" {
+			tokens = append(tokens, part)
+		}
+	}
+
+	var cur strings.Builder
+	var last rune
+	for _, r := range label {
+		if cur.Len() > 0 && unicode.IsDigit(r) != unicode.IsDigit(last) {
+			if t := cur.String(); len(t) > 1 && t != label {
+				tokens = append(tokens, t)
+			}
+			cur.Reset()
+		}
+		if r != '-' {
+			cur.WriteRune(r)
+		}
+		last = r
+	}
+	if t := cur.String(); len(t) > 1 && t != label {
+		tokens = append(tokens, t)
+	}
+	return tokens
+}
@@ -0,0 +1,191 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// chaosDatasetOption switches the plugin from per-domain API queries to
+// Chaos's bulk dataset downloads.
+const chaosDatasetOption = "chaos/datasets"
+
+// chaosIndexURL lists the published per-program dataset archives.
+const chaosIndexURL = "https://chaos-data.projectdiscovery.io/index.json"
+
+// chaosCacheTTL is how long a downloaded dataset is considered fresh.
+const chaosCacheTTL = 24 * time.Hour
+
+// chaos retrieves subdomains from the ProjectDiscovery Chaos dataset,
+// either through the per-domain API or bulk dataset downloads with local
+// caching and freshness checks.
+type chaos struct {
+	name string
+}
+
+type chaosAPIResponse struct {
+	Domain     string   `json:"domain"`
+	Subdomains []string `json:"subdomains"`
+}
+
+type chaosIndexEntry struct {
+	Name        string `json:"name"`
+	URL         string `json:"URL"`
+	LastUpdated string `json:"last_updated"`
+}
+
+// NewChaos returns the ProjectDiscovery Chaos plugin.
+func NewChaos() types.Plugin {
+	return &chaos{name: "Chaos"}
+}
+
+func (c *chaos) Name() string {
+	return c.name
+}
+
+func (c *chaos) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     c,
+		Name:       c.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   c.check,
+	})
+}
+
+func (c *chaos) Stop() {}
+
+func (c *chaos) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	if !support.IsRegisteredDomain(cfg, fqdn.Name) || !cfg.IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+
+	if support.OptionEnabled(cfg, chaosDatasetOption) {
+		return c.fromDataset(e, fqdn.Name)
+	}
+	return c.fromAPI(e, fqdn.Name)
+}
+
+func (c *chaos) fromAPI(e *types.Event, domain string) error {
+	key := support.DataSourceKey(e.Session.Config(), c.name)
+	if key == "" {
+		return nil
+	}
+	client := support.NewAPIClient(support.AuthHeaderKey, "Authorization", key).Attributed(e.Session, c.name)
+
+	ctx, cancel := context.WithTimeout(support.EngineContext(), time.Minute)
+	defer cancel()
+
+	resp, err := support.GetJSON[chaosAPIResponse](ctx, client,
+		"https://dns.projectdiscovery.io/dns/"+domain+"/subdomains")
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range resp.Subdomains {
+		if sub != "" && sub != "*" {
+			support.SubmitFQDNGuess(e, sub+"."+domain)
+		}
+	}
+	return nil
+}
+
+// fromDataset serves the domain from the bulk dataset archives, downloading
+// and caching them locally and refreshing stale copies.
+func (c *chaos) fromDataset(e *types.Event, domain string) error {
+	ctx, cancel := context.WithTimeout(support.EngineContext(), 10*time.Minute)
+	defer cancel()
+
+	index, err := support.GetJSON[[]chaosIndexEntry](ctx,
+		support.NewAPIClient(support.AuthNone, "", "").Attributed(e.Session, c.name), chaosIndexURL)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range *index {
+		path, err := c.cachedArchive(ctx, e, &entry)
+		if err != nil {
+			continue
+		}
+		if err := c.scanArchive(e, path, domain); err != nil {
+			e.Logger().Warn("failed to scan the Chaos dataset",
+				"dataset", entry.Name, "err", err)
+		}
+	}
+	return nil
+}
+
+// cachedArchive returns a local copy of the dataset, downloading it only
+// when the cache is missing or stale relative to the index.
+func (c *chaos) cachedArchive(ctx context.Context, e *types.Event, entry *chaosIndexEntry) (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		base = os.TempDir()
+	}
+
+	dir := filepath.Join(base, "amass-engine", "chaos")
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, entry.Name+".zip")
+
+	if info, err := os.Stat(path); err == nil {
+		updated, perr := time.Parse(time.RFC3339, entry.LastUpdated)
+		if time.Since(info.ModTime()) < chaosCacheTTL &&
+			(perr != nil || info.ModTime().After(updated)) {
+			return path, nil
+		}
+	}
+
+	data, err := support.NewAPIClient(support.AuthNone, "", "").Attributed(e.Session, c.name).Get(ctx, entry.URL)
+	if err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, data, 0640)
+}
+
+// scanArchive reads the zipped subdomain lists and submits matching names.
+func (c *chaos) scanArchive(e *types.Event, path, domain string) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = zr.Close() }()
+
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			continue
+		}
+
+		scanner := bufio.NewScanner(rc)
+		for scanner.Scan() {
+			name := strings.ToLower(strings.TrimSpace(scanner.Text()))
+			if name == domain || strings.HasSuffix(name, "."+domain) {
+				support.SubmitFQDNGuess(e, name)
+			}
+		}
+		_ = rc.Close()
+	}
+	return nil
+}
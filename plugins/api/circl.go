@@ -0,0 +1,196 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/config/config"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamcert "github.com/owasp-amass/open-asset-model/certificate"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	oamnet "github.com/owasp-amass/open-asset-model/network"
+)
+
+// circlClient builds the basic-auth client shared by the CIRCL plugins.
+func circlClient(cfg *config.Config, name string) *support.APIClient {
+	creds := support.DataSourceCreds(cfg, name)
+	if creds == nil || creds.Username == "" || creds.Password == "" {
+		return nil
+	}
+
+	client := support.NewAPIClient(support.AuthBasic, "", "")
+	client.Username = creds.Username
+	client.Password = creds.Password
+	return client
+}
+
+// circlPDNS ingests historical resolutions from the CIRCL Passive DNS
+// service for in-scope domains.
+type circlPDNS struct {
+	name string
+}
+
+type circlPDNSRecord struct {
+	RRName string `json:"rrname"`
+	RRType string `json:"rrtype"`
+	RData  string `json:"rdata"`
+}
+
+// NewCIRCLPDNS returns the CIRCL Passive DNS plugin.
+func NewCIRCLPDNS() types.Plugin {
+	return &circlPDNS{name: "CIRCL-PDNS"}
+}
+
+func (c *circlPDNS) Name() string {
+	return c.name
+}
+
+func (c *circlPDNS) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     c,
+		Name:       c.name + "-Handler",
+		Transforms: []string{"fqdn", "ipaddress"},
+		EventType:  oam.FQDN,
+		Callback:   c.check,
+	})
+}
+
+func (c *circlPDNS) Stop() {}
+
+func (c *circlPDNS) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	client := circlClient(cfg, c.name)
+	if client == nil || !support.IsRegisteredDomain(cfg, fqdn.Name) || !cfg.IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+	client = client.Attributed(e.Session, c.name)
+
+	ctx, cancel := context.WithTimeout(support.EngineContext(), 2*time.Minute)
+	defer cancel()
+
+	body, err := client.Get(ctx, "https://www.circl.lu/pdns/query/"+fqdn.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, line := range strings.Split(string(body), "\n") {
+		var record circlPDNSRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			continue
+		}
+		c.ingest(e, &record)
+	}
+	return nil
+}
+
+func (c *circlPDNS) ingest(e *types.Event, record *circlPDNSRecord) {
+	name := strings.ToLower(strings.TrimSuffix(record.RRName, "."))
+	if name == "" || !e.Session.Config().IsDomainInScope(name) {
+		return
+	}
+	support.SubmitFQDNGuess(e, name)
+
+	data := strings.TrimSuffix(strings.TrimSpace(record.RData), ".")
+	switch record.RRType {
+	case "A", "AAAA":
+		if addr, err := netip.ParseAddr(data); err == nil {
+			atype := "IPv4"
+			if addr.Is6() {
+				atype = "IPv6"
+			}
+			support.Graph(e.Session).Enqueue(e.Asset,
+				"historical_"+strings.ToLower(record.RRType)+"_record",
+				&oamnet.IPAddress{Address: addr, Type: atype})
+		}
+	case "CNAME", "NS", "MX":
+		support.Graph(e.Session).Enqueue(e.Asset,
+			"historical_"+strings.ToLower(record.RRType)+"_record",
+			&oamdns.FQDN{Name: strings.ToLower(data)})
+	}
+}
+
+// circlPSSL ingests certificate-to-IP sightings from the CIRCL Passive SSL
+// service for in-scope addresses.
+type circlPSSL struct {
+	name string
+}
+
+type circlPSSLResponse map[string]struct {
+	Certificates []string `json:"certificates"`
+	Subjects     map[string]struct {
+		Values []string `json:"values"`
+	} `json:"subjects"`
+}
+
+// NewCIRCLPSSL returns the CIRCL Passive SSL plugin.
+func NewCIRCLPSSL() types.Plugin {
+	return &circlPSSL{name: "CIRCL-PSSL"}
+}
+
+func (c *circlPSSL) Name() string {
+	return c.name
+}
+
+func (c *circlPSSL) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     c,
+		Name:       c.name + "-Handler",
+		Transforms: []string{"tlscertificate"},
+		EventType:  oam.IPAddress,
+		Callback:   c.check,
+	})
+}
+
+func (c *circlPSSL) Stop() {}
+
+func (c *circlPSSL) check(e *types.Event) error {
+	ip, ok := e.Asset.Asset.(*oamnet.IPAddress)
+	if !ok {
+		return errors.New("failed to extract the IPAddress asset")
+	}
+
+	client := circlClient(e.Session.Config(), c.name)
+	if client == nil {
+		return nil
+	}
+	client = client.Attributed(e.Session, c.name)
+
+	ctx, cancel := context.WithTimeout(support.EngineContext(), 2*time.Minute)
+	defer cancel()
+
+	resp, err := support.GetJSON[circlPSSLResponse](ctx, client,
+		"https://www.circl.lu/v2pssl/query/"+ip.Address.String())
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range *resp {
+		for _, sha1 := range entry.Certificates {
+			cert := &oamcert.TLSCertificate{SerialNumber: sha1}
+			support.Graph(e.Session).Enqueue(e.Asset, "historical_certificate", cert)
+		}
+		for _, subject := range entry.Subjects {
+			for _, value := range subject.Values {
+				for _, name := range support.ExtractNames(e.Session.Config(), value) {
+					support.SubmitFQDNGuess(e, name)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+const (
+	// ctMonitorOption enables issuance monitoring for the session.
+	ctMonitorOption = "ct/monitor"
+	// ctIntervalOption sets, in minutes, how often each zone is polled.
+	ctIntervalOption  = "ct/poll_interval"
+	defaultCTInterval = 15 * time.Minute
+)
+
+// ctWatch monitors certificate transparency logs for new issuance against
+// in-scope zones for the duration of the session, alerting through the
+// findings stream when certificates appear for names not already known.
+type ctWatch struct {
+	name   string
+	client *support.APIClient
+
+	lock     sync.Mutex
+	watching map[string]struct{}
+}
+
+type crtshEntry struct {
+	NameValue string `json:"name_value"`
+	NotBefore string `json:"not_before"`
+	IssuerCN  string `json:"issuer_name"`
+}
+
+// NewCTWatch returns the certificate issuance monitoring plugin.
+func NewCTWatch() types.Plugin {
+	return &ctWatch{
+		name:     "CT-Watch",
+		client:   support.NewAPIClient(support.AuthNone, "", ""),
+		watching: make(map[string]struct{}),
+	}
+}
+
+func (c *ctWatch) Name() string {
+	return c.name
+}
+
+func (c *ctWatch) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     c,
+		Name:       c.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   c.check,
+	})
+}
+
+func (c *ctWatch) Stop() {}
+
+func (c *ctWatch) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	if !support.OptionEnabled(cfg, ctMonitorOption) {
+		return nil
+	}
+	if domain := cfg.WhichDomain(fqdn.Name); domain != fqdn.Name {
+		return nil
+	}
+
+	c.lock.Lock()
+	key := e.Session.ID().String() + "/" + fqdn.Name
+	if _, found := c.watching[key]; found {
+		c.lock.Unlock()
+		return nil
+	}
+	c.watching[key] = struct{}{}
+	c.lock.Unlock()
+
+	// The watcher outlives this callback, so the event must be taken out
+	// of the pool before the dispatcher releases it.
+	types.DisownEvent(e)
+	go c.watch(e, fqdn.Name)
+	return nil
+}
+
+// watch polls the CT logs for the zone until the session completes.
+func (c *ctWatch) watch(e *types.Event, zone string) {
+	interval := defaultCTInterval
+	if minutes := support.OptionInt(e.Session.Config(), ctIntervalOption, 0); minutes > 0 {
+		interval = time.Duration(minutes) * time.Minute
+	}
+
+	// The first poll establishes the baseline; alerts only fire for
+	// issuance observed after monitoring began.
+	seen := make(map[string]struct{})
+	c.poll(e, zone, seen, false)
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for range t.C {
+		if e.Session.Done() {
+			return
+		}
+		c.poll(e, zone, seen, true)
+	}
+}
+
+// poll fetches the zone's CT entries and processes any names not yet seen.
+func (c *ctWatch) poll(e *types.Event, zone string, seen map[string]struct{}, alert bool) {
+	ctx, cancel := context.WithTimeout(support.EngineContext(), time.Minute)
+	defer cancel()
+
+	entries, err := support.GetJSON[[]crtshEntry](ctx, c.client.Attributed(e.Session, c.name),
+		"https://crt.sh/?q="+url.QueryEscape("%."+zone)+"&output=json")
+	if err != nil {
+		return
+	}
+
+	for _, entry := range *entries {
+		for _, name := range strings.Split(entry.NameValue, "\n") {
+			name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "*."))
+			if name == "" {
+				continue
+			}
+			if _, found := seen[name]; found {
+				continue
+			}
+			seen[name] = struct{}{}
+
+			if alert && !support.KnownFQDN(e.Session.ID(), name) {
+				support.AddFinding(e.Session, &support.Finding{
+					Type:        "cert:new_issuance",
+					Asset:       name,
+					Description: "a certificate was issued for the previously unknown name " + name + " by " + entry.IssuerCN,
+					Severity:    "medium",
+					Source:      c.name,
+				})
+			}
+			support.SubmitFQDNGuess(e, name)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	oamnet "github.com/owasp-amass/open-asset-model/network"
+)
+
+// Options providing the time fencing boundaries (Unix epoch seconds)
+// applied to DNSDB queries.
+const (
+	dnsdbTimeFirstAfterOption = "dnsdb/time_first_after"
+	dnsdbTimeLastAfterOption  = "dnsdb/time_last_after"
+)
+
+// dnsdb integrates Farsight DNSDB using both the standard rrset lookup and
+// the Flexible Search endpoints, ingesting historical resolutions with the
+// session's time fencing applied.
+type dnsdb struct {
+	name string
+}
+
+type dnsdbRecord struct {
+	RRName   string          `json:"rrname"`
+	RRType   string          `json:"rrtype"`
+	RData    json.RawMessage `json:"rdata"`
+	TimeLast int64           `json:"time_last"`
+}
+
+// NewDNSDB returns the Farsight DNSDB plugin.
+func NewDNSDB() types.Plugin {
+	return &dnsdb{name: "DNSDB"}
+}
+
+func (d *dnsdb) Name() string {
+	return d.name
+}
+
+func (d *dnsdb) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     d,
+		Name:       d.name + "-Handler",
+		Transforms: []string{"fqdn", "ipaddress"},
+		EventType:  oam.FQDN,
+		Callback:   d.check,
+	})
+}
+
+func (d *dnsdb) Stop() {}
+
+func (d *dnsdb) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	key := support.DataSourceKey(cfg, d.name)
+	if key == "" || !support.IsRegisteredDomain(cfg, fqdn.Name) || !cfg.IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+
+	client := support.NewAPIClient(support.AuthHeaderKey, "X-API-Key", key).Attributed(e.Session, d.name)
+	fence := d.timeFence(e)
+
+	ctx, cancel := context.WithTimeout(support.EngineContext(), 2*time.Minute)
+	defer cancel()
+
+	// The standard lookup returns complete rrsets below the domain.
+	d.query(ctx, e, client,
+		"https://api.dnsdb.info/dnsdb/v2/lookup/rrset/name/*."+fqdn.Name+"?limit=1000"+fence)
+	// Flexible Search surfaces names matching the domain elsewhere in rdata.
+	d.query(ctx, e, client,
+		"https://api.dnsdb.info/dnsdb/v2/regex/rdata/"+fqdn.Name+"?limit=1000"+fence)
+	return nil
+}
+
+// timeFence builds the query parameters bounding how far back results go.
+func (d *dnsdb) timeFence(e *types.Event) string {
+	var fence string
+
+	cfg := e.Session.Config()
+	if v := support.OptionInt(cfg, dnsdbTimeFirstAfterOption, 0); v > 0 {
+		fence += "&time_first_after=" + strconv.Itoa(v)
+	}
+	if v := support.OptionInt(cfg, dnsdbTimeLastAfterOption, 0); v > 0 {
+		fence += "&time_last_after=" + strconv.Itoa(v)
+	}
+	return fence
+}
+
+// query performs one Streaming API request and ingests the returned rrsets.
+func (d *dnsdb) query(ctx context.Context, e *types.Event, client *support.APIClient, url string) {
+	body, err := client.Get(ctx, url)
+	if err != nil {
+		return
+	}
+
+	// SAF responses carry one JSON object per line wrapped in "obj".
+	for _, line := range strings.Split(string(body), "\n") {
+		var wrapper struct {
+			Obj dnsdbRecord `json:"obj"`
+		}
+		if err := json.Unmarshal([]byte(line), &wrapper); err != nil {
+			continue
+		}
+		d.ingest(e, &wrapper.Obj)
+	}
+}
+
+// ingest records a historical resolution from one rrset member.
+func (d *dnsdb) ingest(e *types.Event, record *dnsdbRecord) {
+	name := strings.ToLower(strings.TrimSuffix(record.RRName, "."))
+	if name == "" || !e.Session.Config().IsDomainInScope(name) {
+		return
+	}
+	support.SubmitFQDNGuess(e, name)
+
+	var rdata []string
+	if err := json.Unmarshal(record.RData, &rdata); err != nil {
+		var single string
+		if err := json.Unmarshal(record.RData, &single); err != nil {
+			return
+		}
+		rdata = append(rdata, single)
+	}
+
+	for _, data := range rdata {
+		data = strings.TrimSuffix(strings.TrimSpace(data), ".")
+
+		switch record.RRType {
+		case "A", "AAAA":
+			if addr, err := netip.ParseAddr(data); err == nil {
+				atype := "IPv4"
+				if addr.Is6() {
+					atype = "IPv6"
+				}
+				support.Graph(e.Session).Enqueue(e.Asset, "historical_"+strings.ToLower(record.RRType)+"_record",
+					&oamnet.IPAddress{Address: addr, Type: atype})
+			}
+		case "CNAME", "NS", "MX":
+			support.Graph(e.Session).Enqueue(e.Asset, "historical_"+strings.ToLower(record.RRType)+"_record",
+				&oamdns.FQDN{Name: strings.ToLower(data)})
+		}
+	}
+}
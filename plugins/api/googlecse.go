@@ -0,0 +1,116 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	"github.com/owasp-amass/open-asset-model/contact"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// googleCSE queries the Google Custom Search JSON API as a ToS-friendly
+// alternative to scraping, extracting hostnames and emails from result URLs
+// and snippets. The search engine identifier comes from the data source
+// configuration's secret field.
+type googleCSE struct {
+	name string
+}
+
+type googleCSEResponse struct {
+	Items []struct {
+		Link    string `json:"link"`
+		Snippet string `json:"snippet"`
+	} `json:"items"`
+	Queries struct {
+		NextPage []struct {
+			StartIndex int `json:"startIndex"`
+		} `json:"nextPage"`
+	} `json:"queries"`
+}
+
+// NewGoogleCSE returns the Google Programmable Search plugin.
+func NewGoogleCSE() types.Plugin {
+	return &googleCSE{name: "GoogleCSE"}
+}
+
+func (g *googleCSE) Name() string {
+	return g.name
+}
+
+func (g *googleCSE) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     g,
+		Name:       g.name + "-Handler",
+		Transforms: []string{"fqdn", "emailaddress"},
+		EventType:  oam.FQDN,
+		Callback:   g.check,
+	})
+}
+
+func (g *googleCSE) Stop() {}
+
+func (g *googleCSE) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	creds := support.DataSourceCreds(cfg, g.name)
+	if creds == nil || creds.Apikey == "" || creds.Secret == "" {
+		return nil
+	}
+	if !support.IsRegisteredDomain(cfg, fqdn.Name) || !cfg.IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+
+	client := support.NewAPIClient(support.AuthQueryParam, "key", creds.Apikey).Attributed(e.Session, g.name)
+	p := &support.Paginator{MaxPages: 10, Delay: time.Second, MaxRetries: 1}
+
+	_, err := p.Run(support.EngineContext(), func(ctx context.Context, cursor string) (string, error) {
+		start := 1
+		if cursor != "" {
+			start, _ = strconv.Atoi(cursor)
+		}
+
+		resp, err := support.GetJSON[googleCSEResponse](ctx, client,
+			"https://www.googleapis.com/customsearch/v1?cx="+url.QueryEscape(creds.Secret)+
+				"&q="+url.QueryEscape("site:"+fqdn.Name)+"&start="+strconv.Itoa(start))
+		if err != nil {
+			return "", err
+		}
+		if len(resp.Items) == 0 {
+			return "", support.ErrStopPagination
+		}
+
+		for _, item := range resp.Items {
+			g.process(e, item.Link+" "+item.Snippet)
+		}
+		if next := resp.Queries.NextPage; len(next) > 0 {
+			return strconv.Itoa(next[0].StartIndex), nil
+		}
+		return "", nil
+	})
+	return err
+}
+
+func (g *googleCSE) process(e *types.Event, text string) {
+	cfg := e.Session.Config()
+
+	for _, name := range support.ExtractNames(cfg, text) {
+		support.SubmitFQDNGuess(e, name)
+	}
+	for _, email := range support.ExtractEmails(cfg, text) {
+		support.Graph(e.Session).Enqueue(e.Asset, "mentions", &contact.EmailAddress{Address: email})
+	}
+}
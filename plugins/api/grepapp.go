@@ -0,0 +1,110 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	"github.com/owasp-amass/open-asset-model/contact"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// grepApp searches code indexed by grep.app for mentions of in-scope
+// domains, extracting both email addresses and hostnames from the matched
+// snippets, including results found in configuration-like files.
+type grepApp struct {
+	name   string
+	client *support.APIClient
+}
+
+type grepAppResponse struct {
+	Hits struct {
+		Total int `json:"total"`
+		Hits  []struct {
+			Content struct {
+				Snippet string `json:"snippet"`
+			} `json:"content"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+// NewGrepApp returns the grep.app code search plugin.
+func NewGrepApp() types.Plugin {
+	return &grepApp{
+		name:   "GrepApp",
+		client: support.NewAPIClient(support.AuthNone, "", ""),
+	}
+}
+
+func (g *grepApp) Name() string {
+	return g.name
+}
+
+func (g *grepApp) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     g,
+		Name:       g.name + "-Handler",
+		Transforms: []string{"fqdn", "emailaddress"},
+		EventType:  oam.FQDN,
+		Callback:   g.check,
+	})
+}
+
+func (g *grepApp) Stop() {}
+
+func (g *grepApp) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	if !support.IsRegisteredDomain(cfg, fqdn.Name) || !cfg.IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+
+	p := &support.Paginator{MaxPages: 10, Delay: 2 * time.Second, MaxRetries: 1}
+	_, err := p.Run(support.EngineContext(), func(ctx context.Context, cursor string) (string, error) {
+		page := 1
+		if cursor != "" {
+			page, _ = strconv.Atoi(cursor)
+		}
+
+		resp, err := support.GetJSON[grepAppResponse](ctx, g.client.Attributed(e.Session, g.name),
+			"https://grep.app/api/search?q="+url.QueryEscape(fqdn.Name)+"&page="+strconv.Itoa(page))
+		if err != nil {
+			return "", err
+		}
+		if len(resp.Hits.Hits) == 0 {
+			return "", support.ErrStopPagination
+		}
+
+		for _, hit := range resp.Hits.Hits {
+			g.process(e, hit.Content.Snippet)
+		}
+		return strconv.Itoa(page + 1), nil
+	})
+	return err
+}
+
+// process extracts emails and hostnames from a snippet, dispatching the
+// hostnames as FQDN guesses with code-derived provenance.
+func (g *grepApp) process(e *types.Event, snippet string) {
+	cfg := e.Session.Config()
+
+	for _, email := range support.ExtractEmails(cfg, snippet) {
+		support.Graph(e.Session).Enqueue(e.Asset, "mentions", &contact.EmailAddress{Address: email})
+	}
+	for _, name := range support.ExtractNames(cfg, snippet) {
+		support.SubmitFQDNGuess(e, name)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	"github.com/owasp-amass/open-asset-model/contact"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	"github.com/owasp-amass/open-asset-model/people"
+)
+
+// hunterIO queries the hunter.io domain-search endpoint, ingesting email
+// addresses along with the person and role metadata the response carries:
+// first/last names, positions, and confidence scores.
+type hunterIO struct {
+	name string
+}
+
+type hunterIOResponse struct {
+	Data struct {
+		Emails []struct {
+			Value      string `json:"value"`
+			FirstName  string `json:"first_name"`
+			LastName   string `json:"last_name"`
+			Position   string `json:"position"`
+			Confidence int    `json:"confidence"`
+		} `json:"emails"`
+	} `json:"data"`
+	Meta struct {
+		Results int `json:"results"`
+		Offset  int `json:"offset"`
+	} `json:"meta"`
+}
+
+// NewHunterIO returns the hunter.io domain-search plugin.
+func NewHunterIO() types.Plugin {
+	return &hunterIO{name: "HunterIO"}
+}
+
+func (h *hunterIO) Name() string {
+	return h.name
+}
+
+func (h *hunterIO) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     h,
+		Name:       h.name + "-Handler",
+		Transforms: []string{"emailaddress", "person"},
+		EventType:  oam.FQDN,
+		Callback:   h.check,
+	})
+}
+
+func (h *hunterIO) Stop() {}
+
+func (h *hunterIO) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	key := support.DataSourceKey(cfg, h.name)
+	if key == "" || !support.IsRegisteredDomain(cfg, fqdn.Name) || !cfg.IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+
+	client := support.NewAPIClient(support.AuthQueryParam, "api_key", key).Attributed(e.Session, h.name)
+	p := &support.Paginator{MaxPages: 10, Delay: time.Second, MaxRetries: 1}
+
+	_, err := p.Run(support.EngineContext(), func(ctx context.Context, cursor string) (string, error) {
+		offset := 0
+		if cursor != "" {
+			offset, _ = strconv.Atoi(cursor)
+		}
+
+		resp, err := support.GetJSON[hunterIOResponse](ctx, client,
+			"https://api.hunter.io/v2/domain-search?domain="+fqdn.Name+
+				"&limit=100&offset="+strconv.Itoa(offset))
+		if err != nil {
+			return "", err
+		}
+		if len(resp.Data.Emails) == 0 {
+			return "", support.ErrStopPagination
+		}
+
+		for _, record := range resp.Data.Emails {
+			h.process(e, record.Value, record.FirstName, record.LastName,
+				record.Position, record.Confidence)
+		}
+		return strconv.Itoa(offset + len(resp.Data.Emails)), nil
+	})
+	return err
+}
+
+// process stores the email along with the associated person and role
+// metadata, carrying the provider confidence into the provenance layer.
+func (h *hunterIO) process(e *types.Event, email, first, last, position string, confidence int) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return
+	}
+
+	addr, err := support.Graph(e.Session).Create(e.Asset, "mentions", &contact.EmailAddress{Address: email})
+	if err != nil || addr == nil {
+		return
+	}
+	support.AddProperty(e.Session, email, "confidence", strconv.Itoa(confidence))
+
+	full := strings.TrimSpace(first + " " + last)
+	if full == "" {
+		return
+	}
+
+	person, err := support.Graph(e.Session).Create(addr, "belongs_to", &people.Person{
+		FullName:   full,
+		FirstName:  first,
+		FamilyName: last,
+	})
+	if err != nil || person == nil {
+		return
+	}
+	if position != "" {
+		support.AddProperty(e.Session, full, "position", position)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// leakIX queries the LeakIX host and leak endpoints, attaching service
+// banners, open ports, and leak summaries to the corresponding assets
+// instead of only extracting subdomain names.
+type leakIX struct {
+	name string
+}
+
+type leakIXResult struct {
+	Host      string `json:"host"`
+	Port      string `json:"port"`
+	Protocol  string `json:"protocol"`
+	Summary   string `json:"summary"`
+	EventType string `json:"event_type"`
+	Service   struct {
+		Software struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"software"`
+	} `json:"service"`
+	Leak struct {
+		Severity string `json:"severity"`
+		Dataset  struct {
+			Rows int `json:"rows"`
+		} `json:"dataset"`
+	} `json:"leak"`
+}
+
+// NewLeakIX returns the LeakIX services and leaks plugin.
+func NewLeakIX() types.Plugin {
+	return &leakIX{name: "LeakIX"}
+}
+
+func (l *leakIX) Name() string {
+	return l.name
+}
+
+func (l *leakIX) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     l,
+		Name:       l.name + "-Handler",
+		Transforms: []string{"fqdn", "ipaddress"},
+		EventType:  oam.FQDN,
+		Callback:   l.check,
+	})
+}
+
+func (l *leakIX) Stop() {}
+
+func (l *leakIX) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	if !cfg.IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+
+	client := support.NewAPIClient(support.AuthHeaderKey, "api-key",
+		support.DataSourceKey(cfg, l.name)).Attributed(e.Session, l.name)
+
+	ctx, cancel := context.WithTimeout(support.EngineContext(), time.Minute)
+	defer cancel()
+
+	results, err := support.GetJSON[[]leakIXResult](ctx, client,
+		"https://leakix.net/api/host/"+fqdn.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, result := range *results {
+		l.process(e, &result)
+	}
+	return nil
+}
+
+// process attaches the service and leak context to the related asset and
+// raises findings for confirmed leaks.
+func (l *leakIX) process(e *types.Event, result *leakIXResult) {
+	host := result.Host
+	if host == "" {
+		return
+	}
+	support.SubmitFQDNGuess(e, host)
+
+	if result.Port != "" {
+		support.AddProperty(e.Session, host, "open_port", result.Port+"/"+result.Protocol)
+	}
+	if sw := result.Service.Software.Name; sw != "" {
+		banner := sw
+		if result.Service.Software.Version != "" {
+			banner += " " + result.Service.Software.Version
+		}
+		support.AddProperty(e.Session, host, "banner", banner)
+	}
+
+	if result.EventType == "leak" {
+		severity := result.Leak.Severity
+		if severity == "" {
+			severity = "medium"
+		}
+
+		desc := "LeakIX reported an exposure on port " + result.Port
+		if result.Summary != "" {
+			desc = result.Summary
+		}
+		if rows := result.Leak.Dataset.Rows; rows > 0 {
+			desc += " (" + strconv.Itoa(rows) + " rows)"
+		}
+
+		support.AddFinding(e.Session, &support.Finding{
+			Type:        "leak:exposure",
+			Asset:       host,
+			Description: desc,
+			Severity:    severity,
+			Source:      l.name,
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// Options configuring the MISP instance and the optional push of session
+// findings back into it as a MISP event.
+const (
+	mispURLOption  = "misp/url"
+	mispPushOption = "misp/push_findings"
+)
+
+// misp enriches in-scope assets with attributes from a MISP instance and
+// can push session findings back as a MISP event when configured to.
+type misp struct {
+	name   string
+	client *http.Client
+}
+
+type mispSearchResponse struct {
+	Response struct {
+		Attribute []struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+			Event struct {
+				Info string `json:"info"`
+			} `json:"Event"`
+		} `json:"Attribute"`
+	} `json:"response"`
+}
+
+// NewMISP returns the MISP threat intel enrichment plugin.
+func NewMISP() types.Plugin {
+	return &misp{
+		name:   "MISP",
+		client: &http.Client{Timeout: time.Minute},
+	}
+}
+
+func (m *misp) Name() string {
+	return m.name
+}
+
+func (m *misp) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     m,
+		Name:       m.name + "-Handler",
+		Transforms: []string{"fqdn", "ipaddress"},
+		EventType:  oam.FQDN,
+		Callback:   m.check,
+	})
+}
+
+func (m *misp) Stop() {}
+
+func (m *misp) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	base := strings.TrimSuffix(support.OptionString(cfg, mispURLOption, ""), "/")
+	key := support.DataSourceKey(cfg, m.name)
+	if base == "" || key == "" || !cfg.IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+
+	resp, err := m.request(base, key, "/attributes/restSearch", map[string]interface{}{
+		"value":        fqdn.Name,
+		"searchall":    1,
+		"returnFormat": "json",
+	})
+	if err != nil {
+		return err
+	}
+
+	var result mispSearchResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return err
+	}
+
+	for _, attr := range result.Response.Attribute {
+		support.AddProperty(e.Session, fqdn.Name, "misp:"+attr.Type, attr.Value)
+
+		if attr.Event.Info != "" {
+			support.AddFinding(e.Session, &support.Finding{
+				Type:        "intel:misp",
+				Asset:       fqdn.Name,
+				Description: "MISP attribute observed in event: " + attr.Event.Info,
+				Severity:    "medium",
+				Source:      m.name,
+			})
+		}
+	}
+
+	if support.OptionEnabled(cfg, mispPushOption) {
+		m.push(e, base, key)
+	}
+	return nil
+}
+
+// push publishes the findings collected so far as a MISP event.
+func (m *misp) push(e *types.Event, base, key string) {
+	findings := support.SessionFindings(e.Session.ID())
+	if len(findings) == 0 {
+		return
+	}
+
+	var attributes []map[string]string
+	for _, f := range findings {
+		attributes = append(attributes, map[string]string{
+			"type":     "comment",
+			"category": "External analysis",
+			"value":    f.Type + " on " + f.Asset + ": " + f.Description,
+		})
+	}
+
+	_, err := m.request(base, key, "/events/add", map[string]interface{}{
+		"Event": map[string]interface{}{
+			"info":      "amass engine session " + e.Session.ID().String(),
+			"Attribute": attributes,
+		},
+	})
+	if err != nil {
+		e.Logger().Warn("failed to push findings to MISP",
+			"err", err)
+	}
+}
+
+// request performs an authenticated JSON POST to the MISP API.
+func (m *misp) request(base, key, path string, payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(support.EngineContext(), time.Minute)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, base+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", key)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, errors.New("the MISP request failed: " + resp.Status)
+	}
+
+	buf := new(bytes.Buffer)
+	_, err = buf.ReadFrom(resp.Body)
+	return buf.Bytes(), err
+}
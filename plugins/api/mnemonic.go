@@ -0,0 +1,117 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	oamnet "github.com/owasp-amass/open-asset-model/network"
+)
+
+// mnemonic pivots from in-scope IP addresses through the Mnemonic passive
+// DNS dataset to domains that historically resolved to them. Matches are
+// recorded with low confidence and the observation time range, since old
+// resolutions only suggest related infrastructure.
+type mnemonic struct {
+	name   string
+	client *support.APIClient
+}
+
+type mnemonicResponse struct {
+	Data []struct {
+		Query         string `json:"query"`
+		Answer        string `json:"answer"`
+		RRType        string `json:"rrtype"`
+		FirstSeen     int64  `json:"firstSeenTimestamp"`
+		LastSeen      int64  `json:"lastSeenTimestamp"`
+		TimesObserved int    `json:"times"`
+	} `json:"data"`
+}
+
+// NewMnemonic returns the historical IP-to-domain pivot plugin.
+func NewMnemonic() types.Plugin {
+	return &mnemonic{
+		name:   "Mnemonic",
+		client: support.NewAPIClient(support.AuthNone, "", ""),
+	}
+}
+
+func (m *mnemonic) Name() string {
+	return m.name
+}
+
+func (m *mnemonic) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     m,
+		Name:       m.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.IPAddress,
+		Callback:   m.check,
+	})
+}
+
+func (m *mnemonic) Stop() {}
+
+func (m *mnemonic) check(e *types.Event) error {
+	ip, ok := e.Asset.Asset.(*oamnet.IPAddress)
+	if !ok {
+		return errors.New("failed to extract the IPAddress asset")
+	}
+
+	ctx, cancel := context.WithTimeout(support.EngineContext(), 30*time.Second)
+	defer cancel()
+
+	resp, err := support.GetJSON[mnemonicResponse](ctx, m.client.Attributed(e.Session, m.name),
+		"https://api.mnemonic.no/pdns/v3/"+ip.Address.String())
+	if err != nil {
+		return err
+	}
+
+	cfg := e.Session.Config()
+	for _, record := range resp.Data {
+		name := record.Query
+		if name == "" || cfg.WhichDomain(name) == "" {
+			continue
+		}
+
+		a, err := support.Graph(e.Session).Create(e.Asset, "historical_resolution", &oamdns.FQDN{Name: name})
+		if err != nil || a == nil {
+			continue
+		}
+
+		// The relation carries its observation window so consumers can
+		// judge how stale the pivot is.
+		if record.FirstSeen > 0 {
+			support.AddProperty(e.Session, name, "first_seen",
+				time.Unix(record.FirstSeen/1000, 0).Format(time.RFC3339))
+		}
+		if record.LastSeen > 0 {
+			support.AddProperty(e.Session, name, "last_seen",
+				time.Unix(record.LastSeen/1000, 0).Format(time.RFC3339))
+		}
+		support.SetProvenance(e.Session, name, &support.Provenance{
+			Source:     m.name,
+			Class:      support.ProvenanceScraped,
+			Confidence: 30,
+		})
+
+		support.AddFinding(e.Session, &support.Finding{
+			Type:        "infrastructure:historical",
+			Asset:       name,
+			Description: name + " previously resolved to " + ip.Address.String() + " (observed " + strconv.Itoa(record.TimesObserved) + " times)",
+			Severity:    "info",
+			Source:      m.name,
+		})
+		support.SubmitFQDNGuess(e, name)
+	}
+	return nil
+}
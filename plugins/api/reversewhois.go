@@ -0,0 +1,258 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// maxReverseWhoisResults caps how many domains a single registrant search
+// may propose: common registrant emails belong to registrars and hosting
+// resellers, and an uncapped search would flood the approval queue.
+const maxReverseWhoisResults = 100
+
+// reverseWhois looks up the registrant attributes of each in-scope root
+// domain and searches reverse WHOIS for other domains registered with the
+// same organization or email address. The domains found are proposed as
+// scope candidates rather than submitted directly, since they are new
+// roots that require approval before enumeration.
+type reverseWhois struct {
+	name string
+}
+
+var revWhoisLock sync.Mutex
+var revWhoisSearched = make(map[uuid.UUID]map[string]struct{})
+
+func init() {
+	support.OnSessionCleanup(func(id uuid.UUID) {
+		revWhoisLock.Lock()
+		delete(revWhoisSearched, id)
+		revWhoisLock.Unlock()
+	})
+}
+
+// NewReverseWhois returns the reverse WHOIS horizontal-discovery plugin.
+func NewReverseWhois() types.Plugin {
+	return &reverseWhois{name: "ReverseWhois"}
+}
+
+func (r *reverseWhois) Name() string {
+	return r.name
+}
+
+func (r *reverseWhois) Start(reg types.Registry) error {
+	return reg.RegisterHandler(&types.Handler{
+		Plugin:     r,
+		Name:       r.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   r.check,
+	})
+}
+
+func (r *reverseWhois) Stop() {}
+
+func (r *reverseWhois) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	if !support.IsRegisteredDomain(cfg, fqdn.Name) || !cfg.IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+	if !r.firstSearch(e.Session.ID(), fqdn.Name) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(support.EngineContext(), 2*time.Minute)
+	defer cancel()
+
+	if key := support.DataSourceKey(cfg, "WhoisXMLAPI"); key != "" {
+		return r.whoisXML(ctx, e, fqdn.Name, key)
+	}
+	if key := support.DataSourceKey(cfg, "SecurityTrails"); key != "" {
+		return r.securityTrails(ctx, e, fqdn.Name, key)
+	}
+	return nil
+}
+
+// firstSearch returns true only the first time the domain is seen for the
+// session, so each registrant is searched once.
+func (r *reverseWhois) firstSearch(id uuid.UUID, domain string) bool {
+	revWhoisLock.Lock()
+	defer revWhoisLock.Unlock()
+
+	if _, found := revWhoisSearched[id]; !found {
+		revWhoisSearched[id] = make(map[string]struct{})
+	}
+	if _, found := revWhoisSearched[id][domain]; found {
+		return false
+	}
+	revWhoisSearched[id][domain] = struct{}{}
+	return true
+}
+
+type whoisXMLRecord struct {
+	WhoisRecord struct {
+		Registrant struct {
+			Organization string `json:"organization"`
+			Email        string `json:"email"`
+		} `json:"registrant"`
+	} `json:"WhoisRecord"`
+}
+
+type whoisXMLReverse struct {
+	DomainsList []string `json:"domainsList"`
+}
+
+// whoisXML fetches the WHOIS record for the domain and searches the
+// WhoisXML reverse WHOIS endpoint for the registrant terms found.
+func (r *reverseWhois) whoisXML(ctx context.Context, e *types.Event, domain, key string) error {
+	client := support.NewAPIClient(support.AuthQueryParam, "apiKey", key).Attributed(e.Session, r.name)
+
+	record, err := support.GetJSON[whoisXMLRecord](ctx, client,
+		"https://www.whoisxmlapi.com/whoisserver/WhoisService?outputFormat=JSON&domainName="+domain)
+	if err != nil {
+		return err
+	}
+
+	term := usableRegistrantTerm(record.WhoisRecord.Registrant.Email,
+		record.WhoisRecord.Registrant.Organization)
+	if term == "" {
+		return nil
+	}
+
+	payload := map[string]interface{}{
+		"apiKey":     key,
+		"searchType": "current",
+		"mode":       "purchase",
+		"basicSearchTerms": map[string][]string{
+			"include": {term},
+		},
+	}
+	resp, err := support.PostJSON[whoisXMLReverse](ctx, client,
+		"https://reverse-whois.whoisxmlapi.com/api/v2", payload)
+	if err != nil {
+		return err
+	}
+
+	r.propose(e, domain, term, resp.DomainsList)
+	return nil
+}
+
+type securityTrailsWhois struct {
+	Contacts []struct {
+		Type         string `json:"type"`
+		Email        string `json:"email"`
+		Organization string `json:"organization"`
+	} `json:"contacts"`
+}
+
+type securityTrailsDomains struct {
+	Records []struct {
+		Hostname string `json:"hostname"`
+	} `json:"records"`
+}
+
+// securityTrails fetches the WHOIS contacts for the domain and searches
+// the SecurityTrails domain list by the registrant email found.
+func (r *reverseWhois) securityTrails(ctx context.Context, e *types.Event, domain, key string) error {
+	client := support.NewAPIClient(support.AuthHeaderKey, "APIKEY", key).Attributed(e.Session, r.name)
+
+	whois, err := support.GetJSON[securityTrailsWhois](ctx, client,
+		"https://api.securitytrails.com/v1/domain/"+domain+"/whois")
+	if err != nil {
+		return err
+	}
+
+	var email string
+	for _, contact := range whois.Contacts {
+		if contact.Type != "" && !strings.Contains(strings.ToLower(contact.Type), "registrant") {
+			continue
+		}
+		if email = usableRegistrantTerm(contact.Email, ""); email != "" {
+			break
+		}
+	}
+	if email == "" {
+		return nil
+	}
+
+	payload := map[string]interface{}{
+		"filter": map[string]string{"whois_email": email},
+	}
+	resp, err := support.PostJSON[securityTrailsDomains](ctx, client,
+		"https://api.securitytrails.com/v1/domains/list", payload)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, record := range resp.Records {
+		names = append(names, record.Hostname)
+	}
+	r.propose(e, domain, email, names)
+	return nil
+}
+
+// propose queues each discovered root domain as a pending scope candidate.
+func (r *reverseWhois) propose(e *types.Event, seed, term string, names []string) {
+	seen := make(map[string]struct{})
+
+	var count int
+	for _, name := range names {
+		if count >= maxReverseWhoisResults {
+			break
+		}
+
+		root := support.RegisteredDomain(e.Session.Config(), strings.TrimSpace(name))
+		if root == "" || root == seed {
+			continue
+		}
+		if _, found := seen[root]; found {
+			continue
+		}
+		seen[root] = struct{}{}
+
+		support.ProposeScopeCandidate(e.Session, root, r.name,
+			"shares the registrant "+term+" with "+seed)
+		count++
+	}
+	if count > 0 {
+		support.IncrementStat(e.Session, r.name, "candidates", int64(count))
+	}
+}
+
+// usableRegistrantTerm returns the email when present, falling back to the
+// organization, and rejects the privacy-service placeholders that would
+// match unrelated domains.
+func usableRegistrantTerm(email, org string) string {
+	term := strings.ToLower(strings.TrimSpace(email))
+	if term == "" {
+		term = strings.ToLower(strings.TrimSpace(org))
+	}
+	if term == "" {
+		return ""
+	}
+
+	for _, word := range []string{"privacy", "redacted", "proxy", "whoisguard", "protect", "private"} {
+		if strings.Contains(term, word) {
+			return ""
+		}
+	}
+	return term
+}
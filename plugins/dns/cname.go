@@ -0,0 +1,132 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+
+	"github.com/miekg/dns"
+	dbt "github.com/owasp-amass/asset-db/types"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	oamnet "github.com/owasp-amass/open-asset-model/network"
+)
+
+// maxChainDepthOption caps how many CNAME hops are followed in one pass.
+const maxChainDepthOption = "dns/max_cname_depth"
+
+const defaultMaxChainDepth int = 10
+
+// dnsCNAME follows CNAME chains hop-by-hop up to a configurable depth,
+// detecting loops, recording the full chain as relations, and dispatching
+// the terminal address records in a single pass.
+type dnsCNAME struct {
+	name string
+}
+
+// NewCNAME returns the plugin handling CNAME resource records.
+func NewCNAME() types.Plugin {
+	return &dnsCNAME{name: "DNS-CNAME"}
+}
+
+func (d *dnsCNAME) Name() string {
+	return d.name
+}
+
+func (d *dnsCNAME) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     d,
+		Name:       d.name + "-Handler",
+		Priority:   1,
+		Transforms: []string{"fqdn", "ipaddress"},
+		EventType:  oam.FQDN,
+		Callback:   d.check,
+	})
+}
+
+func (d *dnsCNAME) Stop() {}
+
+func (d *dnsCNAME) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	maxdepth := support.OptionInt(e.Session.Config(), maxChainDepthOption, defaultMaxChainDepth)
+	seen := map[string]struct{}{fqdn.Name: {}}
+
+	cur := e.Asset
+	name := fqdn.Name
+	for depth := 0; depth < maxdepth; depth++ {
+		rr, err := support.PerformQuery(e.Session, name, dns.TypeCNAME)
+		if err != nil || len(rr) == 0 {
+			break
+		}
+
+		target := strings.ToLower(strings.TrimSuffix(rr[0].Data, "."))
+		if _, found := seen[target]; found {
+			e.Logger().Warn("CNAME loop detected",
+				"target", target)
+			return nil
+		}
+		seen[target] = struct{}{}
+
+		next, err := support.Graph(e.Session).Create(cur, "cname_record", &oamdns.FQDN{Name: target})
+		if err != nil || next == nil {
+			return err
+		}
+		_ = e.Dispatcher.DispatchEvent(&types.Event{
+			Name:        target,
+			Asset:       next,
+			Session:     e.Session,
+			Dispatcher:  e.Dispatcher,
+			Correlation: e.Correlation,
+		})
+		cur, name = next, target
+	}
+
+	if cur != e.Asset {
+		d.terminal(e, cur, name)
+	}
+	return nil
+}
+
+// terminal resolves the end of the chain and records its address records.
+func (d *dnsCNAME) terminal(e *types.Event, tail *dbt.Asset, name string) {
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		rr, err := support.PerformQuery(e.Session, name, qtype)
+		if err != nil {
+			continue
+		}
+
+		rtype := "a_record"
+		atype := "IPv4"
+		if qtype == dns.TypeAAAA {
+			rtype = "aaaa_record"
+			atype = "IPv6"
+		}
+		for _, record := range rr {
+			addr, err := netip.ParseAddr(record.Data)
+			if err != nil {
+				continue
+			}
+
+			ip := &oamnet.IPAddress{Address: addr, Type: atype}
+			if a, err := support.Graph(e.Session).Create(tail, rtype, ip); err == nil && a != nil {
+				_ = e.Dispatcher.DispatchEvent(&types.Event{
+					Name:        record.Data,
+					Asset:       a,
+					Session:     e.Session,
+					Dispatcher:  e.Dispatcher,
+					Correlation: e.Correlation,
+				})
+			}
+		}
+	}
+}
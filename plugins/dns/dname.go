@@ -0,0 +1,67 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// dnsDNAME handles DNAME resource records by recording the redirection on
+// the graph and dispatching the substituted name for further processing.
+type dnsDNAME struct {
+	name string
+}
+
+// NewDNAME returns the plugin handling DNAME resource records.
+func NewDNAME() types.Plugin {
+	return &dnsDNAME{name: "DNS-DNAME"}
+}
+
+func (d *dnsDNAME) Name() string {
+	return d.name
+}
+
+func (d *dnsDNAME) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     d,
+		Name:       d.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   d.check,
+	})
+}
+
+func (d *dnsDNAME) Stop() {}
+
+func (d *dnsDNAME) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	rr, err := support.PerformQuery(e.Session, fqdn.Name, dns.TypeDNAME)
+	if err != nil || len(rr) == 0 {
+		return nil
+	}
+
+	target := strings.ToLower(strings.TrimSuffix(rr[0].Data, "."))
+	if a, err := support.Graph(e.Session).Create(e.Asset, "dname_record", &oamdns.FQDN{Name: target}); err == nil && a != nil {
+		_ = e.Dispatcher.DispatchEvent(&types.Event{
+			Name:        target,
+			Asset:       a,
+			Session:     e.Session,
+			Dispatcher:  e.Dispatcher,
+			Correlation: e.Correlation,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// emailPosture analyzes the SPF and DMARC publications of in-scope domains
+// and raises findings for missing DMARC, weak policies, and overly broad
+// SPF, surfacing them through the findings API and exports.
+type emailPosture struct {
+	name string
+}
+
+// NewEmailPosture returns the plugin producing email posture findings.
+func NewEmailPosture() types.Plugin {
+	return &emailPosture{name: "Email-Posture"}
+}
+
+func (d *emailPosture) Name() string {
+	return d.name
+}
+
+func (d *emailPosture) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     d,
+		Name:       d.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   d.check,
+	})
+}
+
+func (d *emailPosture) Stop() {}
+
+func (d *emailPosture) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+	if domain := e.Session.Config().WhichDomain(fqdn.Name); domain != fqdn.Name {
+		return nil
+	}
+
+	d.checkDMARC(e, fqdn.Name)
+	d.checkSPF(e, fqdn.Name)
+	return nil
+}
+
+// checkDMARC raises findings for missing DMARC records and monitoring-only
+// policies.
+func (d *emailPosture) checkDMARC(e *types.Event, domain string) {
+	record := dmarcRecord(e.Session, domain)
+	if record == "" {
+		d.finding(e, domain, "email:dmarc_missing", "medium",
+			"the domain publishes no DMARC record, so spoofed mail is not rejected")
+		return
+	}
+
+	policy := dmarcTag(record, "p")
+	switch policy {
+	case "none":
+		d.finding(e, domain, "email:dmarc_weak", "low",
+			"the DMARC policy is p=none, which only monitors and never rejects spoofed mail")
+	case "":
+		d.finding(e, domain, "email:dmarc_weak", "low",
+			"the DMARC record is missing its required policy tag")
+	}
+	if sub := dmarcTag(record, "sp"); sub == "none" && policy != "none" {
+		d.finding(e, domain, "email:dmarc_weak", "low",
+			"the DMARC subdomain policy is sp=none while the domain policy is stricter")
+	}
+}
+
+// checkSPF raises findings for absent or overly broad SPF records.
+func (d *emailPosture) checkSPF(e *types.Event, domain string) {
+	record := spfRecord(e.Session, domain)
+	if record == "" {
+		d.finding(e, domain, "email:spf_missing", "medium",
+			"the domain publishes no SPF record")
+		return
+	}
+
+	terms := strings.Fields(record)
+	var lookups int
+	for _, term := range terms {
+		switch {
+		case term == "+all" || term == "all" || term == "?all":
+			d.finding(e, domain, "email:spf_broad", "high",
+				"the SPF record ends in "+term+", authorizing any sender")
+		case strings.HasPrefix(term, "include:") || strings.HasPrefix(term, "redirect="),
+			term == "a" || term == "mx",
+			strings.HasPrefix(term, "a:") || strings.HasPrefix(term, "mx:"),
+			strings.HasPrefix(term, "exists:"):
+			lookups++
+		}
+	}
+	if lookups > 10 {
+		d.finding(e, domain, "email:spf_broad", "low",
+			"the SPF record requires more than the ten DNS lookups evaluators permit")
+	}
+}
+
+func (d *emailPosture) finding(e *types.Event, domain, ftype, severity, description string) {
+	support.AddFinding(e.Session, &support.Finding{
+		Type:        ftype,
+		Asset:       domain,
+		Description: description,
+		Severity:    severity,
+		Source:      d.name,
+	})
+}
+
+// dmarcRecord returns the domain's DMARC record, or an empty string when
+// none is published.
+func dmarcRecord(session types.Session, domain string) string {
+	rr, err := support.PerformQuery(session, "_dmarc."+domain, dns.TypeTXT)
+	if err != nil {
+		return ""
+	}
+
+	for _, record := range rr {
+		if txt := strings.Trim(record.Data, "\""); strings.HasPrefix(txt, "v=DMARC1") {
+			return txt
+		}
+	}
+	return ""
+}
+
+// dmarcTag extracts one tag value from a DMARC record.
+func dmarcTag(record, tag string) string {
+	for _, part := range strings.Split(record, ";") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, tag+"=") {
+			return strings.ToLower(strings.TrimPrefix(part, tag+"="))
+		}
+	}
+	return ""
+}
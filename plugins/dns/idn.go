@@ -0,0 +1,80 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"errors"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// dnsIDN keeps both representations of internationalized names on the graph:
+// whenever an FQDN containing IDN labels is processed, the counterpart
+// A-label or U-label form is created and related to the original asset.
+type dnsIDN struct {
+	name string
+}
+
+// NewIDN returns the plugin handling internationalized domain names.
+func NewIDN() types.Plugin {
+	return &dnsIDN{name: "DNS-IDN"}
+}
+
+func (d *dnsIDN) Name() string {
+	return d.name
+}
+
+func (d *dnsIDN) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     d,
+		Name:       d.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   d.check,
+	})
+}
+
+func (d *dnsIDN) Stop() {}
+
+func (d *dnsIDN) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+	if !support.IsIDN(fqdn.Name) {
+		return nil
+	}
+
+	alabel, err := support.ALabel(fqdn.Name)
+	if err != nil {
+		return err
+	}
+	ulabel, err := support.ULabel(fqdn.Name)
+	if err != nil {
+		return err
+	}
+
+	other := alabel
+	if fqdn.Name == alabel {
+		other = ulabel
+	}
+	if other == fqdn.Name {
+		return nil
+	}
+
+	if a, err := support.Graph(e.Session).Create(e.Asset, "idn_alias", &oamdns.FQDN{Name: other}); err == nil && a != nil {
+		_ = e.Dispatcher.DispatchEvent(&types.Event{
+			Name:        other,
+			Asset:       a,
+			Session:     e.Session,
+			Dispatcher:  e.Dispatcher,
+			Correlation: e.Correlation,
+		})
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// mailProviders maps MX target suffixes to well-known third-party mail
+// services used when classifying a domain's mail infrastructure.
+var mailProviders = map[string]string{
+	"aspmx.l.google.com":          "Google Workspace",
+	"googlemail.com":              "Google Workspace",
+	"mail.protection.outlook.com": "Microsoft 365",
+	"pphosted.com":                "Proofpoint",
+	"ppe-hosted.com":              "Proofpoint Essentials",
+	"mimecast.com":                "Mimecast",
+	"barracudanetworks.com":       "Barracuda",
+	"messagelabs.com":             "Broadcom MessageLabs",
+	"mailgun.org":                 "Mailgun",
+	"zoho.com":                    "Zoho Mail",
+}
+
+// dnsMX resolves MX targets for in-scope domains, relates domains to their
+// mail hosts, and detects third-party mail services from MX patterns.
+type dnsMX struct {
+	name string
+}
+
+// NewMX returns the plugin mapping mail infrastructure from MX records.
+func NewMX() types.Plugin {
+	return &dnsMX{name: "DNS-MX"}
+}
+
+func (d *dnsMX) Name() string {
+	return d.name
+}
+
+func (d *dnsMX) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     d,
+		Name:       d.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   d.check,
+	})
+}
+
+func (d *dnsMX) Stop() {}
+
+func (d *dnsMX) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+	if !e.Session.Config().IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+
+	rr, err := support.PerformQuery(e.Session, fqdn.Name, dns.TypeMX)
+	if err != nil {
+		return nil
+	}
+
+	for _, record := range rr {
+		target := strings.ToLower(strings.TrimSuffix(record.Data, "."))
+		if target == "" {
+			continue
+		}
+
+		if a, err := support.Graph(e.Session).Create(e.Asset, "mx_record", &oamdns.FQDN{Name: target}); err == nil && a != nil {
+			_ = e.Dispatcher.DispatchEvent(&types.Event{
+				Name:        target,
+				Asset:       a,
+				Session:     e.Session,
+				Dispatcher:  e.Dispatcher,
+				Correlation: e.Correlation,
+			})
+		}
+		d.classify(e, fqdn.Name, target)
+	}
+	return nil
+}
+
+// classify tags the domain when its MX target matches a known provider.
+func (d *dnsMX) classify(e *types.Event, domain, target string) {
+	for suffix, provider := range mailProviders {
+		if target == suffix || strings.HasSuffix(target, "."+suffix) {
+			support.AddFinding(e.Session, &support.Finding{
+				Type:        "mail:provider",
+				Asset:       domain,
+				Description: "mail for the domain is handled by " + provider,
+				Severity:    "info",
+				Source:      d.name,
+			})
+			return
+		}
+	}
+}
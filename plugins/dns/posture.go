@@ -0,0 +1,124 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// dnsPosture collects CAA, MTA-STS, TLS-RPT, and security.txt data for
+// in-scope domains, storing them as asset properties and surfacing missing
+// or misconfigured policies through the findings layer.
+type dnsPosture struct {
+	name string
+}
+
+// NewPosture returns the plugin collecting security-posture records.
+func NewPosture() types.Plugin {
+	return &dnsPosture{name: "DNS-Posture"}
+}
+
+func (d *dnsPosture) Name() string {
+	return d.name
+}
+
+func (d *dnsPosture) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     d,
+		Name:       d.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   d.check,
+	})
+}
+
+func (d *dnsPosture) Stop() {}
+
+func (d *dnsPosture) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	if !cfg.IsDomainInScope(fqdn.Name) || !support.IsRegisteredDomain(cfg, fqdn.Name) {
+		return nil
+	}
+
+	d.checkCAA(e, fqdn.Name)
+	d.checkTXTPolicy(e, fqdn.Name, "_mta-sts."+fqdn.Name, "v=stsv1", "mta-sts")
+	d.checkTXTPolicy(e, fqdn.Name, "_smtp._tls."+fqdn.Name, "v=tlsrptv1", "tls-rpt")
+	d.checkSecurityTxt(e, fqdn.Name)
+	return nil
+}
+
+func (d *dnsPosture) checkCAA(e *types.Event, name string) {
+	rr, err := support.PerformQuery(e.Session, name, dns.TypeCAA)
+	if err != nil || len(rr) == 0 {
+		support.AddFinding(e.Session, &support.Finding{
+			Type:        "posture:missing-caa",
+			Asset:       name,
+			Description: "the domain publishes no CAA records restricting certificate issuance",
+			Severity:    "low",
+			Source:      d.name,
+		})
+		return
+	}
+	for _, record := range rr {
+		support.AddProperty(e.Session, name, "caa", record.Data)
+	}
+}
+
+// checkTXTPolicy stores the policy record when present and raises a finding
+// when it is absent.
+func (d *dnsPosture) checkTXTPolicy(e *types.Event, asset, query, marker, label string) {
+	rr, err := support.PerformQuery(e.Session, query, dns.TypeTXT)
+	if err == nil {
+		for _, record := range rr {
+			if strings.HasPrefix(strings.ToLower(record.Data), marker) {
+				support.AddProperty(e.Session, asset, label, record.Data)
+				return
+			}
+		}
+	}
+
+	support.AddFinding(e.Session, &support.Finding{
+		Type:        "posture:missing-" + label,
+		Asset:       asset,
+		Description: "the domain does not publish a " + strings.ToUpper(label) + " policy",
+		Severity:    "info",
+		Source:      d.name,
+	})
+}
+
+func (d *dnsPosture) checkSecurityTxt(e *types.Event, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := support.FetchURL(ctx, "https://"+name+"/.well-known/security.txt")
+	if err == nil {
+		support.RecordBandwidth(e.Session, d.name, 0, int64(len(resp.Body)))
+	}
+	if err != nil || resp.StatusCode != 200 || !strings.Contains(resp.Body, "Contact:") {
+		support.AddFinding(e.Session, &support.Finding{
+			Type:        "posture:missing-security-txt",
+			Asset:       name,
+			Description: "the domain does not serve a security.txt contact file",
+			Severity:    "info",
+			Source:      d.name,
+		})
+		return
+	}
+	support.AddProperty(e.Session, name, "security.txt", resp.Body)
+}
@@ -0,0 +1,127 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// dnsProviders maps NS target suffixes to well-known DNS hosting
+// providers, used when classifying where in-scope zones are hosted.
+var dnsProviders = map[string]string{
+	"awsdns":                "Amazon Route 53",
+	"azure-dns.com":         "Azure DNS",
+	"azure-dns.net":         "Azure DNS",
+	"azure-dns.org":         "Azure DNS",
+	"azure-dns.info":        "Azure DNS",
+	"cloudflare.com":        "Cloudflare",
+	"googledomains.com":     "Google Cloud DNS",
+	"nsone.net":             "NS1",
+	"dnsmadeeasy.com":       "DNS Made Easy",
+	"ultradns.com":          "UltraDNS",
+	"ultradns.net":          "UltraDNS",
+	"akam.net":              "Akamai Edge DNS",
+	"domaincontrol.com":     "GoDaddy",
+	"registrar-servers.com": "Namecheap",
+	"digitalocean.com":      "DigitalOcean",
+	"linode.com":            "Linode",
+	"dreamhost.com":         "DreamHost",
+	"he.net":                "Hurricane Electric",
+}
+
+// dnsProvider classifies in-scope zones by DNS hosting provider from their
+// NS record patterns, so reports can group zones by provider and takeover
+// checks can apply provider-specific logic.
+type dnsProvider struct {
+	name string
+}
+
+// NewProvider returns the plugin fingerprinting DNS hosting providers.
+func NewProvider() types.Plugin {
+	return &dnsProvider{name: "DNS-Provider"}
+}
+
+func (d *dnsProvider) Name() string {
+	return d.name
+}
+
+func (d *dnsProvider) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     d,
+		Name:       d.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   d.check,
+	})
+}
+
+func (d *dnsProvider) Stop() {}
+
+func (d *dnsProvider) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+	// Only zone apexes have meaningful NS records for classification.
+	if domain := e.Session.Config().WhichDomain(fqdn.Name); domain != fqdn.Name {
+		return nil
+	}
+
+	rr, err := support.PerformQuery(e.Session, fqdn.Name, dns.TypeNS)
+	if err != nil {
+		return nil
+	}
+
+	for _, record := range rr {
+		target := strings.ToLower(strings.TrimSuffix(record.Data, "."))
+		if target == "" {
+			continue
+		}
+
+		if a, err := support.Graph(e.Session).Create(e.Asset, "ns_record", &oamdns.FQDN{Name: target}); err == nil && a != nil {
+			_ = e.Dispatcher.DispatchEvent(&types.Event{
+				Name:        target,
+				Asset:       a,
+				Session:     e.Session,
+				Dispatcher:  e.Dispatcher,
+				Correlation: e.Correlation,
+			})
+		}
+		if provider := matchProvider(target); provider != "" {
+			support.AddProperty(e.Session, fqdn.Name, "dns:provider", provider)
+			support.AddFinding(e.Session, &support.Finding{
+				Type:        "dns:provider",
+				Asset:       fqdn.Name,
+				Description: "the zone is hosted at " + provider,
+				Severity:    "info",
+				Source:      d.name,
+			})
+			return nil
+		}
+	}
+	return nil
+}
+
+// matchProvider returns the hosting provider whose pattern matches the NS
+// target, or an empty string for unrecognized infrastructure.
+func matchProvider(target string) string {
+	for suffix, provider := range dnsProviders {
+		if target == suffix || strings.HasSuffix(target, "."+suffix) || strings.Contains(target, "."+suffix+"-") {
+			return provider
+		}
+	}
+	// Route 53 name servers embed a numbered label such as awsdns-42.
+	if strings.Contains(target, "awsdns-") {
+		return dnsProviders["awsdns"]
+	}
+	return ""
+}
@@ -0,0 +1,170 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"errors"
+	"net/netip"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	oamnet "github.com/owasp-amass/open-asset-model/network"
+)
+
+// maxSPFDepth bounds include/redirect recursion, matching the ten-lookup
+// limit RFC 7208 imposes on evaluators.
+const maxSPFDepth = 10
+
+// dnsSPF flattens the SPF include chains of in-scope domains, enumerating
+// every netblock and host authorized to send their mail. Senders reached
+// through out-of-scope includes are tagged as third parties, which feeds
+// both discovery and misconfiguration reporting.
+type dnsSPF struct {
+	name string
+}
+
+// NewSPF returns the plugin flattening SPF records into sender assets.
+func NewSPF() types.Plugin {
+	return &dnsSPF{name: "DNS-SPF"}
+}
+
+func (d *dnsSPF) Name() string {
+	return d.name
+}
+
+func (d *dnsSPF) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     d,
+		Name:       d.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   d.check,
+	})
+}
+
+func (d *dnsSPF) Stop() {}
+
+func (d *dnsSPF) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+	cfg := e.Session.Config()
+	if domain := cfg.WhichDomain(fqdn.Name); domain != fqdn.Name {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	d.flatten(e, fqdn.Name, fqdn.Name, seen, 0)
+	return nil
+}
+
+// flatten resolves one domain's SPF record and recurses through include
+// and redirect mechanisms, recording every authorized sender against the
+// original in-scope domain.
+func (d *dnsSPF) flatten(e *types.Event, origin, domain string, seen map[string]struct{}, depth int) {
+	if depth > maxSPFDepth {
+		return
+	}
+	if _, found := seen[domain]; found {
+		return
+	}
+	seen[domain] = struct{}{}
+
+	record := spfRecord(e.Session, domain)
+	if record == "" {
+		return
+	}
+
+	thirdParty := !e.Session.Config().IsDomainInScope(domain)
+	for _, term := range strings.Fields(record) {
+		switch {
+		case strings.HasPrefix(term, "include:"):
+			d.flatten(e, origin, strings.TrimPrefix(term, "include:"), seen, depth+1)
+		case strings.HasPrefix(term, "redirect="):
+			d.flatten(e, origin, strings.TrimPrefix(term, "redirect="), seen, depth+1)
+		case strings.HasPrefix(term, "ip4:") || strings.HasPrefix(term, "ip6:"):
+			d.recordSender(e, origin, term[4:], thirdParty)
+		case term == "a" || term == "mx":
+			d.recordSenderHost(e, origin, domain, thirdParty)
+		case strings.HasPrefix(term, "a:"):
+			d.recordSenderHost(e, origin, strings.TrimPrefix(term, "a:"), thirdParty)
+		case strings.HasPrefix(term, "mx:"):
+			d.recordSenderHost(e, origin, strings.TrimPrefix(term, "mx:"), thirdParty)
+		}
+	}
+}
+
+// recordSender stores one ip4/ip6 mechanism as a netblock or address asset
+// related to the origin domain.
+func (d *dnsSPF) recordSender(e *types.Event, origin, value string, thirdParty bool) {
+	relation := "authorized_sender"
+
+	var a interface{}
+	if prefix, err := netip.ParsePrefix(value); err == nil {
+		block := &oamnet.Netblock{Cidr: prefix, Type: addrLabel(prefix.Addr())}
+		if created, err := support.Graph(e.Session).Create(e.Asset, relation, block); err == nil {
+			a = created
+		}
+	} else if addr, err := netip.ParseAddr(value); err == nil {
+		ip := &oamnet.IPAddress{Address: addr, Type: addrLabel(addr)}
+		if created, err := support.Graph(e.Session).Create(e.Asset, relation, ip); err == nil {
+			a = created
+		}
+	}
+	if a == nil {
+		return
+	}
+
+	if thirdParty {
+		support.AddProperty(e.Session, value, "sender:third_party", "true")
+	}
+	support.AddProperty(e.Session, value, "sender:authorized_for", origin)
+}
+
+// recordSenderHost stores an a/mx mechanism target as a related host.
+func (d *dnsSPF) recordSenderHost(e *types.Event, origin, host string, thirdParty bool) {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" {
+		return
+	}
+
+	if a, err := support.Graph(e.Session).Create(e.Asset, "authorized_sender", &oamdns.FQDN{Name: host}); err != nil || a == nil {
+		return
+	}
+	if thirdParty {
+		support.AddProperty(e.Session, host, "sender:third_party", "true")
+	}
+	support.AddProperty(e.Session, host, "sender:authorized_for", origin)
+	support.SubmitFQDNGuess(e, host)
+}
+
+// spfRecord returns the domain's SPF record, or an empty string when it
+// publishes none.
+func spfRecord(session types.Session, domain string) string {
+	rr, err := support.PerformQuery(session, domain, dns.TypeTXT)
+	if err != nil {
+		return ""
+	}
+
+	for _, record := range rr {
+		if txt := strings.Trim(record.Data, "\""); strings.HasPrefix(txt, "v=spf1") {
+			return txt
+		}
+	}
+	return ""
+}
+
+// addrLabel returns the open-asset-model address type label.
+func addrLabel(addr netip.Addr) string {
+	if addr.Is4() {
+		return "IPv4"
+	}
+	return "IPv6"
+}
@@ -0,0 +1,139 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/miekg/dns"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// verificationTokens maps site-verification token prefixes to the services
+// they indicate a relationship with.
+var verificationTokens = map[string]string{
+	"google-site-verification":      "google.com",
+	"ms=":                           "microsoft.com",
+	"facebook-domain-verification":  "facebook.com",
+	"apple-domain-verification":     "apple.com",
+	"atlassian-domain-verification": "atlassian.com",
+	"stripe-verification":           "stripe.com",
+	"docusign=":                     "docusign.com",
+	"zoom-domain-verification":      "zoom.us",
+}
+
+// dkimSelectors are commonly deployed selectors probed for DKIM records.
+var dkimSelectors = []string{"default", "selector1", "selector2", "google", "k1", "dkim", "mail"}
+
+// dnsTXT extracts intelligence from TXT records: SPF include and redirect
+// mechanisms, DKIM selectors, DMARC policies, and site-verification tokens,
+// creating relations to referenced services and new in-scope hostnames.
+type dnsTXT struct {
+	name string
+}
+
+// NewTXT returns the plugin extracting intelligence from TXT records.
+func NewTXT() types.Plugin {
+	return &dnsTXT{name: "DNS-TXT"}
+}
+
+func (d *dnsTXT) Name() string {
+	return d.name
+}
+
+func (d *dnsTXT) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     d,
+		Name:       d.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   d.check,
+	})
+}
+
+func (d *dnsTXT) Stop() {}
+
+func (d *dnsTXT) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+	if !e.Session.Config().IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+
+	if rr, err := support.PerformQuery(e.Session, fqdn.Name, dns.TypeTXT); err == nil {
+		for _, record := range rr {
+			d.processRecord(e, record.Data)
+		}
+	}
+
+	d.checkDMARC(e, fqdn.Name)
+	d.checkDKIM(e, fqdn.Name)
+	return nil
+}
+
+func (d *dnsTXT) processRecord(e *types.Event, data string) {
+	txt := strings.ToLower(strings.TrimSpace(data))
+
+	if strings.HasPrefix(txt, "v=spf1") {
+		d.processSPF(e, txt)
+		return
+	}
+	for prefix, service := range verificationTokens {
+		if strings.HasPrefix(txt, prefix) {
+			support.Graph(e.Session).Enqueue(e.Asset, "verified_for", &oamdns.FQDN{Name: service})
+			break
+		}
+	}
+}
+
+// processSPF extracts the domains referenced by include and redirect
+// mechanisms and submits the in-scope ones for enumeration.
+func (d *dnsTXT) processSPF(e *types.Event, txt string) {
+	for _, field := range strings.Fields(txt) {
+		var name string
+
+		if strings.HasPrefix(field, "include:") {
+			name = strings.TrimPrefix(field, "include:")
+		} else if strings.HasPrefix(field, "redirect=") {
+			name = strings.TrimPrefix(field, "redirect=")
+		}
+		if name == "" {
+			continue
+		}
+
+		support.Graph(e.Session).Enqueue(e.Asset, "spf_include", &oamdns.FQDN{Name: name})
+		support.SubmitFQDNGuess(e, name)
+	}
+}
+
+func (d *dnsTXT) checkDMARC(e *types.Event, name string) {
+	rr, err := support.PerformQuery(e.Session, "_dmarc."+name, dns.TypeTXT)
+	if err != nil {
+		return
+	}
+
+	for _, record := range rr {
+		if strings.HasPrefix(strings.ToLower(record.Data), "v=dmarc1") {
+			support.Graph(e.Session).Enqueue(e.Asset, "dmarc_record", &oamdns.FQDN{Name: "_dmarc." + name})
+			break
+		}
+	}
+}
+
+func (d *dnsTXT) checkDKIM(e *types.Event, name string) {
+	for _, selector := range dkimSelectors {
+		sub := selector + "._domainkey." + name
+
+		if rr, err := support.PerformQuery(e.Session, sub, dns.TypeTXT); err == nil && len(rr) > 0 {
+			support.Graph(e.Session).Enqueue(e.Asset, "dkim_record", &oamdns.FQDN{Name: sub})
+		}
+	}
+}
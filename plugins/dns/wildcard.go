@@ -0,0 +1,125 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package dns
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+const ldhChars = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// dnsWildcard detects wildcard records within in-scope zones, models them
+// explicitly as assets (e.g. *.dev.example.com), and exposes wildcard
+// presence as a finding so downstream logic can suppress false positives.
+type dnsWildcard struct {
+	name  string
+	mu    sync.Mutex
+	zones map[string]bool
+}
+
+// NewWildcard returns the plugin detecting and modeling wildcard records.
+func NewWildcard() types.Plugin {
+	return &dnsWildcard{
+		name:  "DNS-Wildcard",
+		zones: make(map[string]bool),
+	}
+}
+
+func (d *dnsWildcard) Name() string {
+	return d.name
+}
+
+func (d *dnsWildcard) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     d,
+		Name:       d.name + "-Handler",
+		Priority:   1,
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   d.check,
+	})
+}
+
+func (d *dnsWildcard) Stop() {}
+
+// WildcardZone returns true when a wildcard has been confirmed for the zone
+// holding the provided name during this run.
+func (d *dnsWildcard) WildcardZone(name string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for zone, wild := range d.zones {
+		if wild && (name == zone || strings.HasSuffix(name, "."+zone)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *dnsWildcard) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	labels := strings.SplitN(fqdn.Name, ".", 2)
+	if len(labels) != 2 {
+		return nil
+	}
+	zone := labels[1]
+
+	d.mu.Lock()
+	_, tested := d.zones[zone]
+	d.mu.Unlock()
+	if tested {
+		return nil
+	}
+
+	wild := d.probe(e, zone)
+	d.mu.Lock()
+	d.zones[zone] = wild
+	d.mu.Unlock()
+
+	if wild {
+		d.record(e, zone)
+	}
+	return nil
+}
+
+// probe resolves a random label under the zone to confirm wildcard behavior.
+func (d *dnsWildcard) probe(e *types.Event, zone string) bool {
+	label := make([]byte, 16)
+	for i := range label {
+		label[i] = ldhChars[rand.Intn(len(ldhChars))]
+	}
+
+	rr, err := support.PerformQuery(e.Session, string(label)+"."+zone, dns.TypeA)
+	return err == nil && len(rr) > 0
+}
+
+// record stores the wildcard as an explicit asset and raises the finding.
+func (d *dnsWildcard) record(e *types.Event, zone string) {
+	name := "*." + zone
+	if a, err := support.Graph(e.Session).Create(nil, "", &oamdns.FQDN{Name: name}); err == nil && a != nil {
+		support.Graph(e.Session).Enqueue(a, "wildcard_record", &oamdns.FQDN{Name: zone})
+	}
+
+	support.AddFinding(e.Session, &support.Finding{
+		Type:        "dns:wildcard",
+		Asset:       zone,
+		Description: "the zone responds to arbitrary names with a wildcard record",
+		Severity:    "info",
+		Source:      d.name,
+	})
+}
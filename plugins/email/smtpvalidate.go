@@ -0,0 +1,133 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package email
+
+import (
+	"errors"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	"github.com/owasp-amass/open-asset-model/contact"
+)
+
+// smtpValidateOption explicitly enables SMTP validation of candidate
+// addresses; the stage never runs without this opt-in.
+const smtpValidateOption = "email/smtp_validation"
+
+// mxContactInterval is the minimum delay between probes of the same MX.
+const mxContactInterval = 10 * time.Second
+
+// smtpValidate checks candidate emails against the domain's MX servers by
+// connecting, sending EHLO and RCPT TO, and disconnecting without DATA,
+// validating pattern-generated addresses without burning API credits.
+type smtpValidate struct {
+	name string
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// NewSMTPValidate returns the opt-in SMTP validation plugin.
+func NewSMTPValidate() types.Plugin {
+	return &smtpValidate{
+		name: "Email-SMTP-Validate",
+		last: make(map[string]time.Time),
+	}
+}
+
+func (s *smtpValidate) Name() string {
+	return s.name
+}
+
+func (s *smtpValidate) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     s,
+		Name:       s.name + "-Handler",
+		Transforms: []string{"emailaddress"},
+		EventType:  oam.EmailAddress,
+		Callback:   s.check,
+	})
+}
+
+func (s *smtpValidate) Stop() {}
+
+func (s *smtpValidate) check(e *types.Event) error {
+	email, ok := e.Asset.Asset.(*contact.EmailAddress)
+	if !ok {
+		return errors.New("failed to extract the EmailAddress asset")
+	}
+	if !support.OptionEnabled(e.Session.Config(), smtpValidateOption) {
+		return nil
+	}
+	if !support.HoldForEngagementWindow(e) {
+		return nil
+	}
+
+	parts := strings.SplitN(email.Address, "@", 2)
+	if len(parts) != 2 {
+		return nil
+	}
+
+	rr, err := support.PerformQuery(e.Session, parts[1], dns.TypeMX)
+	if err != nil || len(rr) == 0 {
+		return nil
+	}
+	mx := strings.TrimSuffix(rr[0].Data, ".")
+
+	s.throttle(mx)
+	if valid, err := s.probe(mx, email.Address); err == nil {
+		v := "invalid"
+		if valid {
+			v = "valid"
+		}
+		support.AddProperty(e.Session, email.Address, "smtp_validation", v)
+	}
+	return nil
+}
+
+// throttle enforces the per-MX contact interval.
+func (s *smtpValidate) throttle(mx string) {
+	s.mu.Lock()
+	wait := mxContactInterval - time.Since(s.last[mx])
+	s.last[mx] = time.Now().Add(wait)
+	s.mu.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
+
+// probe performs the EHLO and RCPT TO exchange without sending DATA.
+func (s *smtpValidate) probe(mx, email string) (bool, error) {
+	conn, err := net.DialTimeout("tcp", mx+":25", 15*time.Second)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = conn.Close() }()
+	_ = conn.SetDeadline(time.Now().Add(30 * time.Second))
+
+	client, err := smtp.NewClient(conn, mx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = client.Quit() }()
+
+	if err := client.Hello("verify.local"); err != nil {
+		return false, err
+	}
+	if err := client.Mail("verify@verify.local"); err != nil {
+		return false, err
+	}
+	if err := client.Rcpt(email); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
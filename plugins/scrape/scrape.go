@@ -0,0 +1,100 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package scrape
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// maxPagesOption caps how many pages a paginated scraper will consume.
+const maxPagesOption = "scrape/max_pages"
+
+const defaultMaxPages int = 20
+
+// pageURLFunc builds the URL for the numbered page of a scrape source.
+type pageURLFunc func(domain string, page int) string
+
+// scraper is the common implementation shared by paginated scrape sources:
+// it walks pages until a page yields no new in-scope names, honors the
+// configured page maximum, and records how many pages were consumed in the
+// plugin stats.
+type scraper struct {
+	name    string
+	delay   time.Duration
+	pageURL pageURLFunc
+}
+
+func (s *scraper) Name() string {
+	return s.name
+}
+
+func (s *scraper) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     s,
+		Name:       s.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   s.check,
+	})
+}
+
+func (s *scraper) Stop() {}
+
+func (s *scraper) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	if !support.IsRegisteredDomain(cfg, fqdn.Name) || !cfg.IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+
+	if support.BandwidthExceeded(e.Session) {
+		return nil
+	}
+	if !support.HoldForEngagementWindow(e) {
+		return nil
+	}
+
+	maxpages := support.OptionInt(cfg, maxPagesOption, defaultMaxPages)
+	p := &support.Paginator{MaxPages: maxpages, Delay: s.delay, MaxRetries: 1}
+
+	pages := 0
+	_, err := p.Run(context.Background(), func(ctx context.Context, cursor string) (string, error) {
+		page := 1
+		if cursor != "" {
+			page, _ = strconv.Atoi(cursor)
+		}
+
+		resp, err := support.FetchURL(ctx, s.pageURL(fqdn.Name, page))
+		if err != nil {
+			return "", err
+		}
+		pages++
+		support.RecordBandwidth(e.Session, s.name, 0, int64(len(resp.Body)))
+
+		names := support.ExtractNames(cfg, resp.Body)
+		if len(names) == 0 {
+			return "", support.ErrStopPagination
+		}
+		for _, name := range names {
+			support.SubmitFQDNGuess(e, name)
+		}
+		return strconv.Itoa(page + 1), nil
+	})
+
+	support.IncrementStat(e.Session, s.name, "pages_consumed", int64(pages))
+	return err
+}
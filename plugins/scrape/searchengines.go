@@ -0,0 +1,189 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package scrape
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owasp-amass/config/config"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// dorkTemplatesOption overrides the default search dork templates; the
+// {domain} placeholder is replaced with the target domain.
+const dorkTemplatesOption = "scrape/dorks"
+
+// banCooldown is how long a search engine is left alone after it responds
+// with a CAPTCHA or ban page.
+const banCooldown = 30 * time.Minute
+
+// defaultDorks are the query templates used when none are configured.
+var defaultDorks = []string{
+	"site:{domain} -www.{domain}",
+	"site:{domain} filetype:pdf",
+	"site:{domain} filetype:xlsx",
+}
+
+// banMarkers identify CAPTCHA and ban interstitials in response bodies.
+var banMarkers = []string{"captcha", "unusual traffic", "detected suspicious", "are you a robot"}
+
+// searchEngine scrapes results for dork-templated queries from a search
+// engine, backing off cleanly when a CAPTCHA or ban page is detected.
+type searchEngine struct {
+	name      string
+	delay     time.Duration
+	queryURL  func(query string, page int) string
+	mu        sync.Mutex
+	bannedTil time.Time
+}
+
+// NewBing returns the Bing search scrape plugin.
+func NewBing() types.Plugin {
+	return &searchEngine{
+		name:  "Bing",
+		delay: 3 * time.Second,
+		queryURL: func(query string, page int) string {
+			return "https://www.bing.com/search?q=" + url.QueryEscape(query) +
+				"&first=" + strconv.Itoa((page-1)*10+1)
+		},
+	}
+}
+
+// NewDuckDuckGo returns the DuckDuckGo search scrape plugin.
+func NewDuckDuckGo() types.Plugin {
+	return &searchEngine{
+		name:  "DuckDuckGo",
+		delay: 3 * time.Second,
+		queryURL: func(query string, page int) string {
+			return "https://html.duckduckgo.com/html/?q=" + url.QueryEscape(query) +
+				"&s=" + strconv.Itoa((page-1)*30)
+		},
+	}
+}
+
+func (s *searchEngine) Name() string {
+	return s.name
+}
+
+func (s *searchEngine) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     s,
+		Name:       s.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   s.check,
+	})
+}
+
+func (s *searchEngine) Stop() {}
+
+func (s *searchEngine) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	if !support.IsRegisteredDomain(cfg, fqdn.Name) || !cfg.IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+	if s.banned() {
+		return nil
+	}
+	if !support.HoldForEngagementWindow(e) {
+		return nil
+	}
+
+	for _, dork := range s.dorks(cfg) {
+		query := strings.ReplaceAll(dork, "{domain}", fqdn.Name)
+
+		if err := s.search(e, query); err != nil {
+			return err
+		}
+		if s.banned() {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *searchEngine) dorks(cfg *config.Config) []string {
+	raw := support.OptionString(cfg, dorkTemplatesOption, "")
+	if raw == "" {
+		return defaultDorks
+	}
+
+	var results []string
+	for _, d := range strings.Split(raw, ",") {
+		if d = strings.TrimSpace(d); d != "" {
+			results = append(results, d)
+		}
+	}
+	return results
+}
+
+func (s *searchEngine) search(e *types.Event, query string) error {
+	cfg := e.Session.Config()
+	p := &support.Paginator{MaxPages: 5, Delay: s.delay, MaxRetries: 1}
+
+	_, err := p.Run(context.Background(), func(ctx context.Context, cursor string) (string, error) {
+		page := 1
+		if cursor != "" {
+			page, _ = strconv.Atoi(cursor)
+		}
+
+		resp, err := support.FetchURL(ctx, s.queryURL(query, page))
+		if err != nil {
+			return "", err
+		}
+		support.RecordBandwidth(e.Session, s.name, 0, int64(len(resp.Body)))
+		if s.detectBan(e, resp.Body) {
+			return "", support.ErrStopPagination
+		}
+
+		names := support.ExtractNames(cfg, resp.Body)
+		if len(names) == 0 {
+			return "", support.ErrStopPagination
+		}
+		for _, name := range names {
+			support.SubmitFQDNGuess(e, name)
+		}
+		return strconv.Itoa(page + 1), nil
+	})
+	return err
+}
+
+// detectBan checks the body for CAPTCHA markers and starts the cooldown.
+func (s *searchEngine) detectBan(e *types.Event, body string) bool {
+	lower := strings.ToLower(body)
+
+	for _, marker := range banMarkers {
+		if strings.Contains(lower, marker) {
+			s.mu.Lock()
+			s.bannedTil = time.Now().Add(banCooldown)
+			s.mu.Unlock()
+
+			e.Logger().Warn("search engine requested a CAPTCHA, backing off",
+				"cooldown", banCooldown.String())
+			return true
+		}
+	}
+	return false
+}
+
+func (s *searchEngine) banned() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Now().Before(s.bannedTil)
+}
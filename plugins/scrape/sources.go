@@ -0,0 +1,49 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package scrape
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/owasp-amass/engine/types"
+)
+
+// NewRapidDNS returns the rapiddns.io scrape plugin.
+func NewRapidDNS() types.Plugin {
+	return &scraper{
+		name:  "RapidDNS",
+		delay: 2 * time.Second,
+		pageURL: func(domain string, page int) string {
+			return "https://rapiddns.io/subdomain/" + domain +
+				"?page=" + strconv.Itoa(page) + "&full=1"
+		},
+	}
+}
+
+// NewSiteDossier returns the sitedossier.com scrape plugin.
+func NewSiteDossier() types.Plugin {
+	return &scraper{
+		name:  "SiteDossier",
+		delay: 4 * time.Second,
+		pageURL: func(domain string, page int) string {
+			// SiteDossier numbers result pages by record offset.
+			return "http://www.sitedossier.com/parentdomain/" + domain +
+				"/" + strconv.Itoa((page-1)*100+1)
+		},
+	}
+}
+
+// NewDNSHistory returns the dnshistory.org scrape plugin.
+func NewDNSHistory() types.Plugin {
+	return &scraper{
+		name:  "DNSHistory",
+		delay: 2 * time.Second,
+		pageURL: func(domain string, page int) string {
+			return "https://dnshistory.org/subdomains/" + strconv.Itoa(page) +
+				"/" + domain
+		},
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"sync"
+	"time"
+)
+
+// Bounds for the adaptive resolver rate controller.
+const (
+	aimdMinQPS      float64 = 10
+	aimdMaxQPS      float64 = 2000
+	aimdIncrease    float64 = 25
+	aimdBackoff     float64 = 0.5
+	aimdAdjustEvery         = time.Second
+)
+
+// rateController implements additive-increase/multiplicative-decrease
+// control over the DNS query rate: the rate climbs while responses stay
+// healthy and halves when timeouts or SERVFAILs appear, maximizing
+// throughput without manual per-resolver tuning.
+type rateController struct {
+	sync.Mutex
+	qps        float64
+	tokens     float64
+	lastRefill time.Time
+	lastAdjust time.Time
+	successes  int
+	failures   int
+	// maxQPS is an optional configured ceiling below the controller's own
+	// built-in maximum. Zero leaves only the built-in bound in place.
+	maxQPS float64
+}
+
+var dnsRate = &rateController{
+	qps:        aimdMinQPS * 10,
+	lastRefill: time.Now(),
+	lastAdjust: time.Now(),
+}
+
+// wait blocks until the controller grants a query token.
+func (rc *rateController) wait() {
+	for {
+		rc.Lock()
+		now := time.Now()
+		rc.tokens += rc.qps * now.Sub(rc.lastRefill).Seconds()
+		if rc.tokens > rc.qps {
+			rc.tokens = rc.qps
+		}
+		rc.lastRefill = now
+
+		if rc.tokens >= 1 {
+			rc.tokens--
+			rc.Unlock()
+			return
+		}
+		qps := rc.qps
+		rc.Unlock()
+
+		time.Sleep(time.Duration(float64(time.Second) / qps))
+	}
+}
+
+// report feeds a query outcome back into the controller and periodically
+// adjusts the rate.
+func (rc *rateController) report(healthy bool) {
+	rc.Lock()
+	defer rc.Unlock()
+
+	if healthy {
+		rc.successes++
+	} else {
+		rc.failures++
+	}
+	if time.Since(rc.lastAdjust) < aimdAdjustEvery {
+		return
+	}
+	rc.lastAdjust = time.Now()
+
+	total := rc.successes + rc.failures
+	if total == 0 {
+		return
+	}
+
+	if float64(rc.failures)/float64(total) > 0.05 {
+		rc.qps *= aimdBackoff
+		if rc.qps < aimdMinQPS {
+			rc.qps = aimdMinQPS
+		}
+	} else {
+		rc.qps += aimdIncrease
+		if rc.qps > rc.ceiling() {
+			rc.qps = rc.ceiling()
+		}
+	}
+	rc.successes, rc.failures = 0, 0
+}
+
+// ceiling returns the effective upper bound on the rate. The caller must
+// hold the lock.
+func (rc *rateController) ceiling() float64 {
+	if rc.maxQPS > 0 && rc.maxQPS < aimdMaxQPS {
+		return rc.maxQPS
+	}
+	return aimdMaxQPS
+}
+
+// SetDNSMaxQPS caps the adaptive query rate from session configuration,
+// letting the stealth profile hold resolver load well below what the
+// controller would otherwise climb to. Zero or negative removes the cap.
+func SetDNSMaxQPS(qps int) {
+	dnsRate.Lock()
+	defer dnsRate.Unlock()
+
+	dnsRate.maxQPS = float64(qps)
+	if ceiling := dnsRate.ceiling(); dnsRate.qps > ceiling {
+		dnsRate.qps = ceiling
+	}
+}
+
+// DNSQueryRate returns the current adaptive queries-per-second target.
+func DNSQueryRate() float64 {
+	dnsRate.Lock()
+	defer dnsRate.Unlock()
+	return dnsRate.qps
+}
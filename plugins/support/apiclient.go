@@ -0,0 +1,243 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/owasp-amass/engine/types"
+)
+
+// AuthScheme selects how the APIClient attaches credentials to requests.
+type AuthScheme int
+
+const (
+	// AuthNone sends no credentials.
+	AuthNone AuthScheme = iota
+	// AuthHeaderKey sends the key in a provider-specific header.
+	AuthHeaderKey
+	// AuthQueryParam appends the key as a URL query parameter.
+	AuthQueryParam
+	// AuthBearer sends the key as an OAuth2-style bearer token.
+	AuthBearer
+	// AuthBasic sends username/password basic authentication.
+	AuthBasic
+)
+
+// APIError is the typed error returned for non-success API responses.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Body       string
+	// RetryAfter is populated from rate-limit headers when the provider
+	// indicated when the client may try again.
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("api request failed: %s", e.Status)
+}
+
+// IsRateLimited returns true when the error indicates request throttling.
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsAuthFailure returns true when the credentials were rejected.
+func (e *APIError) IsAuthFailure() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// Quota reports provider rate-limit state parsed from response headers.
+type Quota struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// APIClient wraps the repeated HTTP plumbing found in the api plugins:
+// authentication, JSON decoding, status-code mapping to typed errors, and
+// quota header parsing.
+type APIClient struct {
+	Scheme AuthScheme
+	// Name is the header or query parameter carrying the key, such as
+	// "X-API-Key" or "apikey".
+	Name     string
+	Key      string
+	Username string
+	Password string
+	// LastQuota holds the most recent rate-limit state from the provider.
+	LastQuota Quota
+	// Session and Plugin attribute the transfer sizes of every request to
+	// the session's bandwidth accounting when set.
+	Session types.Session
+	Plugin  string
+
+	client *http.Client
+}
+
+// NewAPIClient returns a client configured for the provider's auth scheme.
+func NewAPIClient(scheme AuthScheme, name, key string) *APIClient {
+	return &APIClient{
+		Scheme: scheme,
+		Name:   name,
+		Key:    key,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Attributed returns a copy of the client recording its transfer sizes
+// against the provided session and plugin, sharing the underlying
+// connections. Long-lived clients held on plugin structs use it to account
+// each request to the session being handled.
+func (c *APIClient) Attributed(session types.Session, plugin string) *APIClient {
+	dup := *c
+	dup.Session = session
+	dup.Plugin = plugin
+	return &dup
+}
+
+// Get performs an authenticated GET request and returns the raw body.
+func (c *APIClient) Get(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.do(req)
+}
+
+// Post performs an authenticated POST request carrying the payload as a
+// JSON body and returns the raw response body.
+func (c *APIClient) Post(ctx context.Context, url string, payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.do(req)
+}
+
+func (c *APIClient) do(req *http.Request) ([]byte, error) {
+	if EmergencyStopped() {
+		return nil, EngineContext().Err()
+	}
+	c.authenticate(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxBodySize))
+	if err != nil {
+		return nil, err
+	}
+	c.parseQuota(resp)
+
+	if c.Session != nil {
+		sent := int64(len(req.URL.String()))
+		if req.ContentLength > 0 {
+			sent += req.ContentLength
+		}
+		RecordBandwidth(c.Session, c.Plugin, sent, int64(len(body)))
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			Body:       string(body),
+			RetryAfter: retryAfter(resp),
+		}
+	}
+	return body, nil
+}
+
+// GetJSON performs an authenticated GET request and decodes the JSON
+// response into a value of the requested type.
+func GetJSON[T any](ctx context.Context, c *APIClient, url string) (*T, error) {
+	body, err := c.Get(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(T)
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// PostJSON performs an authenticated POST request with a JSON body and
+// decodes the JSON response into a value of the requested type.
+func PostJSON[T any](ctx context.Context, c *APIClient, url string, payload interface{}) (*T, error) {
+	body, err := c.Post(ctx, url, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(T)
+	if err := json.Unmarshal(body, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *APIClient) authenticate(req *http.Request) {
+	switch c.Scheme {
+	case AuthHeaderKey:
+		req.Header.Set(c.Name, c.Key)
+	case AuthQueryParam:
+		q := req.URL.Query()
+		q.Set(c.Name, c.Key)
+		req.URL.RawQuery = q.Encode()
+	case AuthBearer:
+		req.Header.Set("Authorization", "Bearer "+c.Key)
+	case AuthBasic:
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+}
+
+// parseQuota extracts rate-limit state from the common header variants.
+func (c *APIClient) parseQuota(resp *http.Response) {
+	for _, h := range []string{"X-RateLimit-Limit", "X-Rate-Limit-Limit", "RateLimit-Limit"} {
+		if v, err := strconv.Atoi(resp.Header.Get(h)); err == nil {
+			c.LastQuota.Limit = v
+			break
+		}
+	}
+	for _, h := range []string{"X-RateLimit-Remaining", "X-Rate-Limit-Remaining", "RateLimit-Remaining"} {
+		if v, err := strconv.Atoi(resp.Header.Get(h)); err == nil {
+			c.LastQuota.Remaining = v
+			break
+		}
+	}
+	for _, h := range []string{"X-RateLimit-Reset", "X-Rate-Limit-Reset", "RateLimit-Reset"} {
+		if v, err := strconv.ParseInt(resp.Header.Get(h), 10, 64); err == nil {
+			c.LastQuota.Reset = time.Unix(v, 0)
+			break
+		}
+	}
+}
+
+func retryAfter(resp *http.Response) time.Duration {
+	if v, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		return time.Duration(v) * time.Second
+	}
+	return 0
+}
@@ -0,0 +1,33 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/owasp-amass/engine/types"
+)
+
+// ArtifactDir returns the directory holding artifacts (screenshots, captured
+// documents, debug output) for the session, creating it when necessary.
+func ArtifactDir(session types.Session) (string, error) {
+	return EngineWorkDir(session.ID().String(), "artifacts")
+}
+
+// SaveArtifact writes the data into the session artifact store and returns
+// the path of the stored file.
+func SaveArtifact(session types.Session, name string, data []byte) (string, error) {
+	dir, err := ArtifactDir(session)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0640); err != nil {
+		return "", err
+	}
+	return path, nil
+}
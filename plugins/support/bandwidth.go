@@ -0,0 +1,75 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/types"
+)
+
+// bandwidthCapOption sets an optional per-session cap, in bytes, across
+// everything sent and received by the plugins.
+const bandwidthCapOption = "session/bandwidth_cap"
+
+// bandwidthUsage tracks bytes sent and received per plugin and per session.
+type bandwidthUsage struct {
+	Sent     int64
+	Received int64
+}
+
+var bwLock sync.Mutex
+var bandwidth = make(map[uuid.UUID]map[string]*bandwidthUsage)
+
+// RecordBandwidth adds the transferred byte counts to the per-plugin and
+// per-session accounting.
+func RecordBandwidth(session types.Session, plugin string, sent, received int64) {
+	if session == nil {
+		return
+	}
+
+	bwLock.Lock()
+	defer bwLock.Unlock()
+
+	id := session.ID()
+	if _, found := bandwidth[id]; !found {
+		bandwidth[id] = make(map[string]*bandwidthUsage)
+	}
+	if _, found := bandwidth[id][plugin]; !found {
+		bandwidth[id][plugin] = &bandwidthUsage{}
+	}
+	bandwidth[id][plugin].Sent += sent
+	bandwidth[id][plugin].Received += received
+}
+
+// SessionBandwidth returns the total bytes sent and received by the session
+// along with the per-plugin breakdown.
+func SessionBandwidth(id uuid.UUID) (int64, int64, map[string]bandwidthUsage) {
+	bwLock.Lock()
+	defer bwLock.Unlock()
+
+	var sent, received int64
+	breakdown := make(map[string]bandwidthUsage)
+	for plugin, usage := range bandwidth[id] {
+		sent += usage.Sent
+		received += usage.Received
+		breakdown[plugin] = *usage
+	}
+	return sent, received, breakdown
+}
+
+// BandwidthExceeded returns true when the session has a configured cap and
+// its accounted transfer total has passed it. Plugins performing network
+// operations should check this before starting new transfers.
+func BandwidthExceeded(session types.Session) bool {
+	cap := int64(OptionInt(session.Config(), bandwidthCapOption, 0))
+	if cap <= 0 {
+		return false
+	}
+
+	sent, received, _ := SessionBandwidth(session.ID())
+	return sent+received >= cap
+}
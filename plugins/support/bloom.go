@@ -0,0 +1,137 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The vast majority of guesses produced during an enumeration are names
+// the session has already seen. A per-session Bloom filter answers "have
+// we seen this FQDN" without a cache or database round trip; false
+// positives only cost a dropped duplicate re-check, never a wrong result
+// on the graph.
+const (
+	// bloomBits sizes each filter; 1<<27 bits is 16MB and keeps the false
+	// positive rate well under 1% at ten million entries with four hashes.
+	bloomBits   = 1 << 27
+	bloomHashes = 4
+)
+
+type bloomFilter struct {
+	bits []uint64
+}
+
+func newBloomFilter() *bloomFilter {
+	return &bloomFilter{bits: make([]uint64, bloomBits/64)}
+}
+
+// positions derives the bit positions for the name from two FNV hashes
+// combined in the standard double-hashing construction.
+func positions(name string) [bloomHashes]uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(name))
+	h1 := h.Sum64()
+
+	h.Reset()
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(name))
+	h2 := h.Sum64()
+
+	var p [bloomHashes]uint64
+	for i := range p {
+		p[i] = (h1 + uint64(i)*h2) % bloomBits
+	}
+	return p
+}
+
+func (b *bloomFilter) test(name string) bool {
+	for _, pos := range positions(name) {
+		if b.bits[pos/64]&(1<<(pos%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (b *bloomFilter) add(name string) {
+	for _, pos := range positions(name) {
+		b.bits[pos/64] |= 1 << (pos % 64)
+	}
+}
+
+// sessionBloom pairs two filter generations so suppression can expire.
+// With no window configured a name is remembered for the session's life;
+// with one, the generations rotate and a name submitted again is suppressed
+// for at least one window and at most two before passing through again.
+type sessionBloom struct {
+	current  *bloomFilter
+	previous *bloomFilter
+	window   time.Duration
+	rotated  time.Time
+}
+
+var bloomLock sync.Mutex
+var blooms = make(map[uuid.UUID]*sessionBloom)
+
+// ConfigureSuppression sets how long the session suppresses duplicates. A
+// zero window restores the default of remembering names forever.
+func ConfigureSuppression(id uuid.UUID, window time.Duration) {
+	bloomLock.Lock()
+	defer bloomLock.Unlock()
+
+	sessionBlooms(id).window = window
+}
+
+// sessionBlooms returns the session's filters, creating them on first use.
+// The caller must hold bloomLock.
+func sessionBlooms(id uuid.UUID) *sessionBloom {
+	b, found := blooms[id]
+	if !found {
+		b = &sessionBloom{current: newBloomFilter(), rotated: time.Now()}
+		blooms[id] = b
+	}
+	return b
+}
+
+// rotate ages out the older generation once the window has elapsed. The
+// caller must hold bloomLock.
+func (s *sessionBloom) rotate() {
+	if s.window <= 0 || time.Since(s.rotated) < s.window {
+		return
+	}
+	s.previous = s.current
+	s.current = newBloomFilter()
+	s.rotated = time.Now()
+}
+
+// KnownFQDN reports whether the session has probably seen the name within
+// the suppression window.
+func KnownFQDN(id uuid.UUID, name string) bool {
+	bloomLock.Lock()
+	defer bloomLock.Unlock()
+
+	b := sessionBlooms(id)
+	b.rotate()
+
+	if b.current.test(name) {
+		return true
+	}
+	return b.previous != nil && b.previous.test(name)
+}
+
+// RememberFQDN records the name in the session's filter.
+func RememberFQDN(id uuid.UUID, name string) {
+	bloomLock.Lock()
+	defer bloomLock.Unlock()
+
+	b := sessionBlooms(id)
+	b.rotate()
+	b.current.add(name)
+}
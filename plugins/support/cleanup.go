@@ -0,0 +1,101 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+var cleanupLock sync.Mutex
+var cleanupHooks []func(uuid.UUID)
+
+// OnSessionCleanup registers a function invoked when a session leaves
+// management, so packages holding their own per-session state can free it
+// alongside the stores this package maintains.
+func OnSessionCleanup(fn func(uuid.UUID)) {
+	cleanupLock.Lock()
+	defer cleanupLock.Unlock()
+	cleanupHooks = append(cleanupHooks, fn)
+}
+
+// CleanupSession frees every per-session store held for the identified
+// session and runs the registered cleanup hooks. Without it, long-running
+// engines accumulate the bloom filters, findings, timelines, and counters
+// of every session they have ever run.
+func CleanupSession(id uuid.UUID) {
+	bwLock.Lock()
+	delete(bandwidth, id)
+	bwLock.Unlock()
+
+	bloomLock.Lock()
+	delete(blooms, id)
+	bloomLock.Unlock()
+
+	correlationLock.Lock()
+	delete(correlations, id)
+	correlationLock.Unlock()
+
+	findingsLock.Lock()
+	delete(findings, id)
+	findingsLock.Unlock()
+
+	freshLock.Lock()
+	delete(freshness, id)
+	freshLock.Unlock()
+
+	graphLock.Lock()
+	delete(graphQueues, id)
+	graphLock.Unlock()
+
+	propLock.Lock()
+	delete(properties, id)
+	propLock.Unlock()
+
+	provLock.Lock()
+	delete(provenance, id)
+	provLock.Unlock()
+
+	samplingLock.Lock()
+	delete(sampling, id)
+	samplingLock.Unlock()
+
+	candLock.Lock()
+	delete(candidates, id)
+	candLock.Unlock()
+
+	statLock.Lock()
+	delete(stats, id)
+	statLock.Unlock()
+
+	timelineLock.Lock()
+	delete(timelines, id)
+	timelineLock.Unlock()
+
+	internLock.Lock()
+	delete(interned, id)
+	internLock.Unlock()
+
+	guessBudgetLock.Lock()
+	delete(guessBudgets, id)
+	guessBudgetLock.Unlock()
+
+	guessLock.Lock()
+	if q, found := guessQueues[id]; found {
+		_ = q.Close()
+		delete(guessQueues, id)
+	}
+	guessLock.Unlock()
+
+	CloseJournal(id)
+
+	cleanupLock.Lock()
+	hooks := append([]func(uuid.UUID){}, cleanupHooks...)
+	cleanupLock.Unlock()
+	for _, fn := range hooks {
+		fn(id)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// compliance holds the engine-wide settings for defensible OSINT practice:
+// honoring robots.txt, identifying with a configured User-Agent and contact
+// URL, and logging every source contacted.
+type complianceState struct {
+	sync.Mutex
+	enabled   bool
+	userAgent string
+	contact   string
+	robots    map[string][]string
+	contacted map[string]struct{}
+}
+
+var compliance = &complianceState{
+	robots:    make(map[string][]string),
+	contacted: make(map[string]struct{}),
+}
+
+// ErrDisallowedByRobots is returned when compliance mode blocks a fetch
+// that the target's robots.txt disallows.
+var ErrDisallowedByRobots = errors.New("the URL is disallowed by robots.txt")
+
+// SetComplianceMode enables or disables the compliance behavior of the HTTP
+// layer and sets the identification the engine presents.
+func SetComplianceMode(enabled bool, userAgent, contactURL string) {
+	compliance.Lock()
+	defer compliance.Unlock()
+
+	compliance.enabled = enabled
+	compliance.userAgent = userAgent
+	compliance.contact = contactURL
+}
+
+// ContactedSources returns the hosts the HTTP layer has contacted while
+// compliance mode was enabled.
+func ContactedSources() []string {
+	compliance.Lock()
+	defer compliance.Unlock()
+
+	var results []string
+	for host := range compliance.contacted {
+		results = append(results, host)
+	}
+	return results
+}
+
+// applyCompliance prepares the outgoing request and reports whether it may
+// proceed under the compliance rules.
+func applyCompliance(req *http.Request) error {
+	compliance.Lock()
+	enabled := compliance.enabled
+	ua := compliance.userAgent
+	contact := compliance.contact
+	compliance.Unlock()
+
+	if !enabled {
+		return nil
+	}
+
+	if ua == "" {
+		ua = "amass-engine"
+	}
+	if contact != "" {
+		ua += " (+" + contact + ")"
+	}
+	req.Header.Set("User-Agent", ua)
+
+	host := req.URL.Hostname()
+	if !robotsAllowed(req.Context(), req.URL) {
+		return ErrDisallowedByRobots
+	}
+
+	compliance.Lock()
+	if _, found := compliance.contacted[host]; !found {
+		compliance.contacted[host] = struct{}{}
+		slog.Info("contacting source", "host", host)
+	}
+	compliance.Unlock()
+	return nil
+}
+
+// robotsAllowed checks the target's robots.txt rules for the wildcard agent,
+// caching the disallowed prefixes per host.
+func robotsAllowed(ctx context.Context, u *url.URL) bool {
+	host := u.Scheme + "://" + u.Host
+
+	compliance.Lock()
+	rules, cached := compliance.robots[host]
+	compliance.Unlock()
+
+	if !cached {
+		rules = fetchRobotsRules(ctx, host)
+
+		compliance.Lock()
+		compliance.robots[host] = rules
+		compliance.Unlock()
+	}
+
+	for _, prefix := range rules {
+		if prefix == "/" || strings.HasPrefix(u.Path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// fetchRobotsRules retrieves the Disallow prefixes applying to all agents.
+func fetchRobotsRules(ctx context.Context, host string) []string {
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, host+"/robots.txt", nil)
+	if err != nil {
+		return nil
+	}
+	resp, err := webClient.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		return nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxBodySize))
+	if err != nil {
+		return nil
+	}
+
+	var rules []string
+	applies := false
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimSpace(line)
+		lower := strings.ToLower(line)
+
+		if strings.HasPrefix(lower, "user-agent:") {
+			applies = strings.TrimSpace(line[len("user-agent:"):]) == "*"
+		} else if applies && strings.HasPrefix(lower, "disallow:") {
+			if prefix := strings.TrimSpace(line[len("disallow:"):]); prefix != "" {
+				rules = append(rules, prefix)
+			}
+		}
+	}
+	return rules
+}
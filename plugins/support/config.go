@@ -0,0 +1,65 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/owasp-amass/config/config"
+)
+
+// OptionEnabled returns true when the named engine option is present in the
+// session configuration and set to a truthy value.
+func OptionEnabled(cfg *config.Config, key string) bool {
+	if cfg == nil || cfg.Options == nil {
+		return false
+	}
+
+	v, found := cfg.Options[key]
+	if !found {
+		return false
+	}
+	switch t := v.(type) {
+	case bool:
+		return t
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(t))
+		return err == nil && b
+	}
+	return false
+}
+
+// OptionInt returns the named engine option as an integer, or the provided
+// default when the option is absent or malformed.
+func OptionInt(cfg *config.Config, key string, def int) int {
+	if cfg == nil || cfg.Options == nil {
+		return def
+	}
+
+	switch t := cfg.Options[key].(type) {
+	case int:
+		return t
+	case float64:
+		return int(t)
+	case string:
+		if i, err := strconv.Atoi(strings.TrimSpace(t)); err == nil {
+			return i
+		}
+	}
+	return def
+}
+
+// OptionString returns the named engine option as a string, or the provided
+// default when the option is absent.
+func OptionString(cfg *config.Config, key string, def string) string {
+	if cfg == nil || cfg.Options == nil {
+		return def
+	}
+	if s, ok := cfg.Options[key].(string); ok && s != "" {
+		return s
+	}
+	return def
+}
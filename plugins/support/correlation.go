@@ -0,0 +1,112 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/types"
+)
+
+// correlationWebhookOption configures a URL receiving a POST notification
+// when a correlated subtree completes.
+const correlationWebhookOption = "correlation/webhook"
+
+// correlationState counts the events still in flight for one correlation
+// ID. The count rises as derived events enter dispatch and falls as their
+// handlers finish; completion fires when it returns to zero.
+type correlationState struct {
+	seed    string
+	pending int
+}
+
+var correlationLock sync.Mutex
+var correlations = make(map[uuid.UUID]map[string]*correlationState)
+
+// CorrelationStarted records that an event carrying the correlation ID has
+// entered dispatch. The first call registers the seed name.
+func CorrelationStarted(id uuid.UUID, correlation, name string) {
+	if correlation == "" {
+		return
+	}
+
+	correlationLock.Lock()
+	defer correlationLock.Unlock()
+
+	if _, found := correlations[id]; !found {
+		correlations[id] = make(map[string]*correlationState)
+	}
+	c, found := correlations[id][correlation]
+	if !found {
+		c = &correlationState{seed: name}
+		correlations[id][correlation] = c
+	}
+	c.pending++
+}
+
+// CorrelationFinished records that an event carrying the correlation ID has
+// left dispatch, notifying the client when it was the last one in flight.
+func CorrelationFinished(session types.Session, correlation string) {
+	if correlation == "" || session == nil {
+		return
+	}
+
+	correlationLock.Lock()
+	id := session.ID()
+	c, found := correlations[id][correlation]
+	if !found {
+		correlationLock.Unlock()
+		return
+	}
+	c.pending--
+	done := c.pending <= 0
+	seed := c.seed
+	if done {
+		delete(correlations[id], correlation)
+	}
+	correlationLock.Unlock()
+
+	if done {
+		notifyCompletion(session, correlation, seed)
+	}
+}
+
+// notifyCompletion surfaces the completion through the findings stream,
+// which the subscription and SSE transports already deliver, and through
+// the webhook when one is configured.
+func notifyCompletion(session types.Session, correlation, seed string) {
+	AddFinding(session, &Finding{
+		Type:        "correlation:complete",
+		Asset:       seed,
+		Description: "all transforms finished for the subtree rooted at " + seed,
+		Severity:    "info",
+		Source:      "correlation:" + correlation,
+	})
+
+	url := OptionString(session.Config(), correlationWebhookOption, "")
+	if url == "" {
+		return
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"session":     session.ID().String(),
+		"correlation": correlation,
+		"seed":        seed,
+		"completed":   time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		return
+	}
+	go func() {
+		if resp, err := http.Post(url, "application/json", bytes.NewReader(body)); err == nil {
+			resp.Body.Close()
+		}
+	}()
+}
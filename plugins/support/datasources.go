@@ -0,0 +1,36 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"github.com/owasp-amass/config/config"
+)
+
+// DataSourceCreds returns the first set of credentials configured for the
+// named data source, falling back to any legacy v3 configuration overlay,
+// or nil when the source has no credentials.
+func DataSourceCreds(cfg *config.Config, name string) *config.Credentials {
+	if cfg == nil {
+		return nil
+	}
+
+	if dsc := cfg.GetDataSourceConfig(name); dsc != nil {
+		for _, cr := range dsc.Creds {
+			if cr != nil {
+				return cr
+			}
+		}
+	}
+	return legacyDataSourceCreds(cfg, name)
+}
+
+// DataSourceKey returns the API key configured for the named data source,
+// or the empty string when the source is not configured.
+func DataSourceKey(cfg *config.Config, name string) string {
+	if cr := DataSourceCreds(cfg, name); cr != nil {
+		return cr.Apikey
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/types"
+)
+
+// The debug capture options. Capture is off until a plugin list or an
+// asset pattern is configured, and even then only a sampled subset of
+// events is recorded, so it can be left enabled against production
+// sessions while a misbehaving transform is investigated.
+const (
+	// debugPluginsOption is a comma-separated list of plugin names to
+	// capture, or "*" for all of them.
+	debugPluginsOption = "debug/capture/plugins"
+	// debugPatternOption restricts capture to assets containing the value.
+	debugPatternOption = "debug/capture/pattern"
+	// debugRateOption is the percentage of matching events captured.
+	debugRateOption = "debug/capture/rate"
+)
+
+const defaultDebugRate int = 10
+
+// debugRecord is the envelope written around each captured payload.
+type debugRecord struct {
+	Time    string `json:"time"`
+	Plugin  string `json:"plugin"`
+	Event   string `json:"event"`
+	Asset   string `json:"asset"`
+	Stage   string `json:"stage"`
+	Payload string `json:"payload"`
+}
+
+// CaptureEnabled reports whether the event was selected for debug capture
+// by the named plugin: the plugin or asset must match the configuration,
+// and the event must fall within the sample.
+func CaptureEnabled(e *types.Event, plugin string) bool {
+	if e == nil || e.Session == nil {
+		return false
+	}
+	cfg := e.Session.Config()
+
+	plugins := OptionString(cfg, debugPluginsOption, "")
+	pattern := OptionString(cfg, debugPatternOption, "")
+	if plugins == "" && pattern == "" {
+		return false
+	}
+	if plugins != "" && !pluginListed(plugins, plugin) {
+		return false
+	}
+	if pattern != "" && !strings.Contains(e.Name, pattern) {
+		return false
+	}
+
+	rate := OptionInt(cfg, debugRateOption, defaultDebugRate)
+	return rand.Intn(100) < rate
+}
+
+// CaptureDebug records one payload from a capture-selected event into the
+// session artifacts for offline analysis.
+func CaptureDebug(e *types.Event, plugin, stage string, payload []byte) {
+	record, err := json.Marshal(&debugRecord{
+		Time:    time.Now().Format(time.RFC3339),
+		Plugin:  plugin,
+		Event:   e.ID.String(),
+		Asset:   e.Name,
+		Stage:   stage,
+		Payload: string(payload),
+	})
+	if err != nil {
+		return
+	}
+
+	name := fmt.Sprintf("debug-%s-%s-%s.json", sanitizeName(plugin), e.ID.String(), sanitizeName(stage))
+	if _, err := SaveArtifact(e.Session, name, record); err != nil {
+		e.Logger().Debug("failed to save the debug capture", "stage", stage, "err", err)
+	}
+}
+
+// pluginListed reports whether the plugin appears in the comma-separated
+// configuration value; "*" matches every plugin.
+func pluginListed(list, plugin string) bool {
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "*" || strings.EqualFold(entry, plugin) {
+			return true
+		}
+	}
+	return false
+}
+
+// sanitizeName makes the value safe for use inside an artifact file name.
+func sanitizeName(v string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, v)
+}
@@ -0,0 +1,77 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"errors"
+
+	"github.com/miekg/dns"
+	"github.com/owasp-amass/engine/types"
+	"github.com/owasp-amass/resolve"
+)
+
+var pool *resolve.Resolvers
+
+func init() {
+	pool = resolve.NewResolvers()
+	_ = pool.AddResolvers(50, "8.8.8.8", "8.8.4.4", "1.1.1.1", "9.9.9.9")
+}
+
+// ResolverPool returns the shared resolver pool used by the DNS plugins.
+func ResolverPool() *resolve.Resolvers {
+	return pool
+}
+
+// PerformQuery resolves the provided name and query type against the shared
+// resolver pool for the session and returns the extracted answers. Queries
+// are pipelined in batches over the shared sockets, and pacing is governed
+// by the adaptive AIMD rate controller. Names answered here also feed the
+// session's freshness tracking, so re-validation starts from the timestamps
+// the original resolutions established.
+func PerformQuery(session types.Session, name string, qtype uint16) ([]*resolve.ExtractedAnswer, error) {
+	dnsRate.wait()
+	countOutbound()
+
+	resp, err := batchQuery(name, qtype)
+	if err != nil {
+		dnsRate.report(false)
+		if session != nil {
+			AddNegative(session.Config(), name)
+		}
+		return nil, err
+	}
+	// The response length is exact; the query is approximated from the name
+	// plus the fixed header and question overhead.
+	RecordBandwidth(session, "dns", int64(len(name)+18), int64(resp.Len()))
+
+	if resp.Rcode == dns.RcodeServerFailure {
+		dnsRate.report(false)
+		return nil, errors.New("the query received SERVFAIL for " + name)
+	}
+
+	dnsRate.report(true)
+	if resp.Rcode != dns.RcodeSuccess {
+		// Names that do not exist are remembered across sessions, so the
+		// same failed guesses are not resolved again until the entry ages
+		// out of the negative cache.
+		if session != nil && resp.Rcode == dns.RcodeNameError {
+			AddNegative(session.Config(), name)
+		}
+		return nil, errors.New("the query was unsuccessful for " + name)
+	}
+
+	if ans := resolve.ExtractAnswers(resp); len(ans) > 0 {
+		MarkValidated(session, name)
+		// A name answering in DNS carries the highest trust class, raising
+		// it past the provenance thresholds the transform gates enforce.
+		SetProvenance(session, name, &Provenance{
+			Source:     "dns",
+			Class:      ProvenanceConfirmed,
+			Confidence: 100,
+		})
+		return resolve.AnswersByType(ans, qtype), nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,178 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/owasp-amass/resolve"
+)
+
+const (
+	// dnsBatchSizeOption caps how many queries are pipelined together over
+	// the shared resolver sockets.
+	dnsBatchSizeOption  = "dns/batch_size"
+	defaultDNSBatchSize = 64
+	// batchFlushInterval bounds how long a partial batch waits for more
+	// queries before being sent anyway.
+	batchFlushInterval = 5 * time.Millisecond
+	batchQueryTimeout  = 10 * time.Second
+)
+
+// dnsRequest is one query waiting to join a batch.
+type dnsRequest struct {
+	name  string
+	qtype uint16
+	out   chan *dns.Msg
+}
+
+// dnsBatcher groups queries and issues each batch through the resolver
+// pool's asynchronous interface, reading the responses interleaved off a
+// shared channel instead of blocking one goroutine per query.
+type dnsBatcher struct {
+	size     atomic.Int64
+	requests chan *dnsRequest
+}
+
+var batcher *dnsBatcher
+
+func init() {
+	batcher = &dnsBatcher{
+		requests: make(chan *dnsRequest, defaultDNSBatchSize*4),
+	}
+	batcher.size.Store(defaultDNSBatchSize)
+	go batcher.run()
+}
+
+// SetDNSBatchSize adjusts the batch size from session configuration. The
+// size is read by the batching goroutine, so the access is atomic.
+func SetDNSBatchSize(size int) {
+	if size > 0 {
+		batcher.size.Store(int64(size))
+	}
+}
+
+// submit queues one query and returns the channel its response arrives on.
+func (b *dnsBatcher) submit(name string, qtype uint16) chan *dns.Msg {
+	out := make(chan *dns.Msg, 1)
+	b.requests <- &dnsRequest{name: name, qtype: qtype, out: out}
+	return out
+}
+
+// run collects requests into batches and flushes each one when it fills or
+// the flush interval expires.
+func (b *dnsBatcher) run() {
+	t := time.NewTicker(batchFlushInterval)
+	defer t.Stop()
+
+	var batch []*dnsRequest
+	for {
+		select {
+		case req := <-b.requests:
+			batch = append(batch, req)
+			if len(batch) >= int(b.size.Load()) {
+				go flush(batch)
+				batch = nil
+			}
+		case <-t.C:
+			if len(batch) > 0 {
+				go flush(batch)
+				batch = nil
+			}
+		}
+	}
+}
+
+// flush issues every query in the batch over the shared pool and reads the
+// responses as they arrive, matching each to its request by question.
+func flush(batch []*dnsRequest) {
+	ctx, cancel := context.WithTimeout(EngineContext(), batchQueryTimeout)
+	defer cancel()
+
+	ch := make(chan *dns.Msg, len(batch))
+	for _, req := range batch {
+		pool.Query(ctx, resolve.QueryMsg(req.name, req.qtype), ch)
+	}
+
+	// Duplicate questions within one batch each keep their own entry, so
+	// every caller receives a response instead of stalling to the timeout.
+	pending := make(map[string][]*dnsRequest, len(batch))
+	for _, req := range batch {
+		key := batchKey(req.name, req.qtype)
+		pending[key] = append(pending[key], req)
+	}
+
+	for range batch {
+		var resp *dns.Msg
+		select {
+		case resp = <-ch:
+		case <-ctx.Done():
+		}
+		if resp == nil || len(resp.Question) == 0 {
+			continue
+		}
+
+		q := resp.Question[0]
+		key := batchKey(q.Name, q.Qtype)
+		if reqs := pending[key]; len(reqs) > 0 {
+			reqs[0].out <- resp
+			pending[key] = reqs[1:]
+		}
+	}
+
+	// Anything unmatched gets an explicit nil so callers never block.
+	for _, reqs := range pending {
+		for _, req := range reqs {
+			req.out <- nil
+		}
+	}
+}
+
+func batchKey(name string, qtype uint16) string {
+	return strings.ToLower(strings.Trim(name, ".")) + "/" + dns.TypeToString[qtype]
+}
+
+// singleQuery issues one query over the shared pool without batching.
+func singleQuery(name string, qtype uint16) (*dns.Msg, error) {
+	ctx, cancel := context.WithTimeout(EngineContext(), batchQueryTimeout)
+	defer cancel()
+
+	ch := make(chan *dns.Msg, 1)
+	pool.Query(ctx, resolve.QueryMsg(name, qtype), ch)
+
+	select {
+	case resp := <-ch:
+		if resp == nil {
+			return nil, errors.New("the query failed for " + name)
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, errors.New("the query timed out for " + name)
+	}
+}
+
+// batchQuery routes one query through the batcher and waits for its
+// response. With the dns-batching feature disabled each query is issued
+// on its own instead of joining a shared batch.
+func batchQuery(name string, qtype uint16) (*dns.Msg, error) {
+	if !FeatureEnabled(nil, "dns-batching") {
+		return singleQuery(name, qtype)
+	}
+
+	select {
+	case resp := <-batcher.submit(name, qtype):
+		if resp == nil {
+			return nil, errors.New("the query failed for " + name)
+		}
+		return resp, nil
+	case <-time.After(batchQueryTimeout + time.Second):
+		return nil, errors.New("the query timed out for " + name)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/owasp-amass/config/config"
+)
+
+var nameRE = regexp.MustCompile(`(?i)[a-z0-9](?:[a-z0-9_-]{0,61}[a-z0-9])?(?:\.[a-z0-9](?:[a-z0-9_-]{0,61}[a-z0-9])?)+`)
+var emailRE = regexp.MustCompile(`(?i)[a-z0-9._%+\-]+@[a-z0-9.\-]+\.[a-z]{2,}`)
+
+// ExtractNames returns the deduplicated, lowercased hostnames found in the
+// provided text that fall within the session scope.
+func ExtractNames(cfg *config.Config, text string) []string {
+	var results []string
+
+	seen := make(map[string]struct{})
+	for _, match := range nameRE.FindAllString(text, -1) {
+		name := strings.ToLower(strings.Trim(match, "."))
+
+		if _, found := seen[name]; found {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		if err := ValidateHostname(name); err != nil {
+			RejectName("extract")
+			continue
+		}
+		if cfg == nil || cfg.IsDomainInScope(name) {
+			results = append(results, name)
+		}
+	}
+	return results
+}
+
+// ExtractEmails returns the deduplicated, lowercased email addresses found
+// in the provided text whose domains fall within the session scope.
+func ExtractEmails(cfg *config.Config, text string) []string {
+	var results []string
+
+	seen := make(map[string]struct{})
+	for _, match := range emailRE.FindAllString(text, -1) {
+		email := strings.ToLower(match)
+
+		if _, found := seen[email]; found {
+			continue
+		}
+		seen[email] = struct{}{}
+
+		parts := strings.SplitN(email, "@", 2)
+		if cfg == nil || (len(parts) == 2 && cfg.IsDomainInScope(parts[1])) {
+			results = append(results, email)
+		}
+	}
+	return results
+}
@@ -0,0 +1,67 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/owasp-amass/config/config"
+)
+
+// Fault-injection options, all disabled by default. Rates are percentages
+// applied independently at each boundary crossing, so users embedding the
+// engine can verify their pipelines tolerate provider flakiness.
+const (
+	faultsEnabledOption = "faults/enabled"
+	faultDelayOption    = "faults/delay_ms"
+	faultErrorOption    = "faults/error_rate"
+	faultDropOption     = "faults/drop_rate"
+)
+
+// ErrFaultInjected is returned for artificially failed operations.
+var ErrFaultInjected = errors.New("an injected fault failed the operation")
+
+// ErrFaultDropped signals that the operation should be silently discarded.
+var ErrFaultDropped = errors.New("an injected fault dropped the operation")
+
+// faultCfg supplies the configuration at boundaries with no session in
+// reach, such as the shared HTTP client.
+var faultCfg *config.Config
+
+// SetFaultConfig points the session-less boundaries at a configuration.
+func SetFaultConfig(cfg *config.Config) {
+	faultCfg = cfg
+}
+
+// MaybeInjectFault rolls the configured fault dice for one boundary
+// crossing. It can delay the caller, return ErrFaultInjected, or return
+// ErrFaultDropped; with injection disabled it always returns nil.
+func MaybeInjectFault(cfg *config.Config, boundary string) error {
+	if !OptionEnabled(cfg, faultsEnabledOption) {
+		return nil
+	}
+
+	if ms := faultSetting(cfg, boundary, faultDelayOption); ms > 0 {
+		// Delays are randomized up to the configured maximum so injected
+		// latency varies the way real provider latency does.
+		time.Sleep(time.Duration(rand.Intn(ms)+1) * time.Millisecond)
+	}
+	if rate := faultSetting(cfg, boundary, faultDropOption); rate > 0 && rand.Intn(100) < rate {
+		return ErrFaultDropped
+	}
+	if rate := faultSetting(cfg, boundary, faultErrorOption); rate > 0 && rand.Intn(100) < rate {
+		return ErrFaultInjected
+	}
+	return nil
+}
+
+// faultSetting reads one fault option, letting a boundary-specific key
+// such as "faults/http/error_rate" override the global one.
+func faultSetting(cfg *config.Config, boundary, option string) int {
+	global := OptionInt(cfg, option, 0)
+	return OptionInt(cfg, "faults/"+boundary+option[len("faults"):], global)
+}
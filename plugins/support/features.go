@@ -0,0 +1,106 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/owasp-amass/config/config"
+)
+
+// Feature is one gated subsystem: experimental features ship dark with a
+// false default and are enabled selectively per engine or per session.
+type Feature struct {
+	Name        string
+	Enabled     bool
+	Description string
+}
+
+// featureDefaults lists the gated subsystems and whether each is on by
+// default. A session enables or disables one with the "feature/<name>"
+// option; SetFeature overrides the default engine-wide.
+var featureDefaults = map[string]*Feature{
+	"graph-batching": {
+		Name:        "graph-batching",
+		Enabled:     true,
+		Description: "batch fire-and-forget graph writes through the GraphWriter queue",
+	},
+	"dns-batching": {
+		Name:        "dns-batching",
+		Enabled:     true,
+		Description: "coalesce resolver queries into shared batches",
+	},
+	// cluster-mode stays on by default because forwarding additionally
+	// requires a configured federation endpoint before anything happens.
+	"cluster-mode": {
+		Name:        "cluster-mode",
+		Enabled:     true,
+		Description: "forward unhandled events to a federated remote engine",
+	},
+}
+
+var featureLock sync.Mutex
+var featureOverrides = make(map[string]bool)
+
+// FeatureEnabled reports whether the named feature is active for the
+// session: the session option wins, then the engine-wide override, then
+// the shipped default. Unknown features are disabled.
+func FeatureEnabled(cfg *config.Config, name string) bool {
+	if cfg != nil {
+		switch OptionString(cfg, "feature/"+name, "") {
+		case "true", "enabled", "on":
+			return true
+		case "false", "disabled", "off":
+			return false
+		}
+	}
+
+	featureLock.Lock()
+	defer featureLock.Unlock()
+
+	if enabled, found := featureOverrides[name]; found {
+		return enabled
+	}
+	if f, found := featureDefaults[name]; found {
+		return f.Enabled
+	}
+	return false
+}
+
+// SetFeature overrides the feature's default for the whole engine. It
+// returns false when the feature is unknown.
+func SetFeature(name string, enabled bool) bool {
+	featureLock.Lock()
+	defer featureLock.Unlock()
+
+	if _, found := featureDefaults[name]; !found {
+		return false
+	}
+	featureOverrides[name] = enabled
+	return true
+}
+
+// Features returns every known feature with its effective engine-wide
+// state, sorted by name.
+func Features() []*Feature {
+	featureLock.Lock()
+	defer featureLock.Unlock()
+
+	var results []*Feature
+	for name, f := range featureDefaults {
+		enabled := f.Enabled
+		if v, found := featureOverrides[name]; found {
+			enabled = v
+		}
+		results = append(results, &Feature{
+			Name:        name,
+			Enabled:     enabled,
+			Description: f.Description,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Name < results[j].Name })
+	return results
+}
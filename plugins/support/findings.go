@@ -0,0 +1,123 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/types"
+)
+
+// Workflow statuses a finding moves through while a team remediates it.
+const (
+	FindingNew           = "new"
+	FindingTriaged       = "triaged"
+	FindingResolved      = "resolved"
+	FindingFalsePositive = "false positive"
+)
+
+// Finding represents a noteworthy observation produced during a session that
+// is not itself an asset, such as a misconfiguration or exposure.
+type Finding struct {
+	Type        string
+	Asset       string
+	Description string
+	Severity    string
+	Source      string
+	Status      string
+	Assignee    string
+	// Suppressed marks a finding against an asset a previous session flagged
+	// as a false positive, keeping the record while excluding it from the
+	// session's results and exports.
+	Suppressed bool
+	Created    time.Time
+}
+
+var findingsLock sync.Mutex
+var findings = make(map[uuid.UUID][]*Finding)
+
+// AddFinding records the finding for the session and logs it.
+func AddFinding(session types.Session, f *Finding) {
+	if session == nil || f == nil {
+		return
+	}
+	if f.Created.IsZero() {
+		f.Created = time.Now()
+	}
+	if f.Status == "" {
+		f.Status = FindingNew
+	}
+	if IsSuppressed(session.Config(), f.Asset) {
+		f.Suppressed = true
+	}
+
+	findingsLock.Lock()
+	findings[session.ID()] = append(findings[session.ID()], f)
+	findingsLock.Unlock()
+
+	session.Log().Info("new finding", "type", f.Type,
+		"asset", f.Asset, "severity", f.Severity, "source", f.Source)
+}
+
+// SessionFindings returns the findings recorded for the identified session,
+// excluding the suppressed ones so results, streams, and exports filter
+// marked false positives automatically.
+func SessionFindings(id uuid.UUID) []*Finding {
+	findingsLock.Lock()
+	defer findingsLock.Unlock()
+
+	var results []*Finding
+	for _, f := range findings[id] {
+		if !f.Suppressed {
+			results = append(results, f)
+		}
+	}
+	return results
+}
+
+// AllSessionFindings returns every finding recorded for the identified
+// session, suppressed ones included, with indices matching those
+// UpdateFinding expects.
+func AllSessionFindings(id uuid.UUID) []*Finding {
+	findingsLock.Lock()
+	defer findingsLock.Unlock()
+
+	results := make([]*Finding, len(findings[id]))
+	copy(results, findings[id])
+	return results
+}
+
+// UpdateFinding mutates the workflow fields of the identified finding.
+// Empty arguments leave the corresponding field unchanged.
+func UpdateFinding(id uuid.UUID, index int, status, assignee, severity string) bool {
+	if status != "" {
+		switch status {
+		case FindingNew, FindingTriaged, FindingResolved, FindingFalsePositive:
+		default:
+			return false
+		}
+	}
+
+	findingsLock.Lock()
+	defer findingsLock.Unlock()
+
+	list := findings[id]
+	if index < 0 || index >= len(list) {
+		return false
+	}
+
+	if status != "" {
+		list[index].Status = status
+	}
+	if assignee != "" {
+		list[index].Assignee = assignee
+	}
+	if severity != "" {
+		list[index].Severity = severity
+	}
+	return true
+}
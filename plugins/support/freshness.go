@@ -0,0 +1,65 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/types"
+)
+
+// freshness tracks when each active asset was last validated so long
+// sessions can distinguish current findings from ones that have gone stale.
+var freshLock sync.Mutex
+var freshness = make(map[uuid.UUID]map[string]time.Time)
+
+// MarkValidated records that the asset was just confirmed to still exist.
+func MarkValidated(session types.Session, asset string) {
+	if session == nil || asset == "" {
+		return
+	}
+
+	freshLock.Lock()
+	defer freshLock.Unlock()
+
+	id := session.ID()
+	if _, found := freshness[id]; !found {
+		freshness[id] = make(map[string]time.Time)
+	}
+	freshness[id][asset] = time.Now()
+}
+
+// LastValidated returns when the asset was last confirmed, or the zero time
+// when it has never been validated.
+func LastValidated(id uuid.UUID, asset string) time.Time {
+	freshLock.Lock()
+	defer freshLock.Unlock()
+	return freshness[id][asset]
+}
+
+// StaleAssets returns the assets whose last validation is older than the
+// provided age, candidates for background re-validation.
+func StaleAssets(id uuid.UUID, age time.Duration) []string {
+	freshLock.Lock()
+	defer freshLock.Unlock()
+
+	var results []string
+	cutoff := time.Now().Add(-age)
+	for asset, when := range freshness[id] {
+		if when.Before(cutoff) {
+			results = append(results, asset)
+		}
+	}
+	return results
+}
+
+// MarkHistorical flags an asset that no longer validates, keeping it on the
+// graph but clearly separated from current findings.
+func MarkHistorical(session types.Session, asset string) {
+	AddProperty(session, asset, "status", "historical")
+	session.Log().Info("asset marked historical", "asset", asset)
+}
@@ -0,0 +1,153 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	dbt "github.com/owasp-amass/asset-db/types"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+)
+
+// graphBatchSize is how many queued writes trigger an immediate flush.
+const graphBatchSize = 32
+
+// graphFlushInterval bounds how long a queued write waits for company.
+const graphFlushInterval = 50 * time.Millisecond
+
+// GraphWriter coordinates asset-database writes across plugins for one
+// session. Plugin-local mutexes only serialize a single plugin against
+// itself; the writer holds the session-wide lock instead, so multi-write
+// sequences from different plugins never interleave. Fire-and-forget
+// writes can additionally be queued and flushed in batches.
+type GraphWriter struct {
+	session types.Session
+}
+
+type graphWrite struct {
+	src      *dbt.Asset
+	relation string
+	asset    oam.Asset
+}
+
+type graphQueue struct {
+	sync.Mutex
+	writes []graphWrite
+	timer  *time.Timer
+}
+
+var graphLock sync.Mutex
+var graphQueues = make(map[uuid.UUID]*graphQueue)
+
+// Graph returns the write coordinator for the session.
+func Graph(session types.Session) *GraphWriter {
+	return &GraphWriter{session: session}
+}
+
+// Create performs the write under the session-wide lock and returns the
+// resulting asset.
+func (g *GraphWriter) Create(src *dbt.Asset, relation string, asset oam.Asset) (*dbt.Asset, error) {
+	if g.session.ReadOnly() {
+		return nil, errors.New("the session must not modify the attached database")
+	}
+	q := g.queue()
+
+	q.Lock()
+	defer q.Unlock()
+
+	g.flushLocked(q)
+	g.observe(src, relation, asset)
+
+	a, err := g.session.DB().Create(src, relation, asset)
+	if err == nil && a != nil {
+		// The database keeps the stored interval: creation preserves the
+		// existing last_seen on duplicates, so every sighting bumps it
+		// explicitly and time-travel queries work across runs.
+		_ = g.session.DB().UpdateAssetLastSeen(a.ID)
+	}
+	return a, err
+}
+
+// Enqueue queues a fire-and-forget write, flushing once the batch fills
+// or the flush interval expires. With the graph-batching feature disabled
+// the write happens synchronously instead.
+func (g *GraphWriter) Enqueue(src *dbt.Asset, relation string, asset oam.Asset) {
+	if g.session.ReadOnly() {
+		return
+	}
+	if !FeatureEnabled(g.session.Config(), "graph-batching") {
+		_, _ = g.Create(src, relation, asset)
+		return
+	}
+	q := g.queue()
+
+	q.Lock()
+	defer q.Unlock()
+
+	q.writes = append(q.writes, graphWrite{src: src, relation: relation, asset: asset})
+	if len(q.writes) >= graphBatchSize {
+		g.flushLocked(q)
+		return
+	}
+	if q.timer == nil {
+		q.timer = time.AfterFunc(graphFlushInterval, func() {
+			q.Lock()
+			defer q.Unlock()
+			g.flushLocked(q)
+		})
+	}
+}
+
+// Flush writes out anything still queued for the session.
+func (g *GraphWriter) Flush() {
+	q := g.queue()
+
+	q.Lock()
+	defer q.Unlock()
+	g.flushLocked(q)
+}
+
+// flushLocked drains the queue while holding its lock. Synchronous writes
+// call it first so queued writes never land after ones submitted later.
+func (g *GraphWriter) flushLocked(q *graphQueue) {
+	if q.timer != nil {
+		q.timer.Stop()
+		q.timer = nil
+	}
+	for _, w := range q.writes {
+		g.observe(w.src, w.relation, w.asset)
+		if a, err := g.session.DB().Create(w.src, w.relation, w.asset); err == nil && a != nil {
+			_ = g.session.DB().UpdateAssetLastSeen(a.ID)
+		}
+	}
+	q.writes = nil
+}
+
+// observe feeds the write into the session timeline so time-travel queries
+// can reconstruct the graph as it stood at any point.
+func (g *GraphWriter) observe(src *dbt.Asset, relation string, asset oam.Asset) {
+	id := g.session.ID()
+
+	dst := assetKey(asset)
+	ObserveAsset(id, dst)
+	if src != nil && relation != "" {
+		ObserveRelation(id, assetKey(src.Asset), relation, dst)
+	}
+}
+
+func (g *GraphWriter) queue() *graphQueue {
+	graphLock.Lock()
+	defer graphLock.Unlock()
+
+	id := g.session.ID()
+	if _, found := graphQueues[id]; !found {
+		graphQueues[id] = &graphQueue{}
+	}
+	return graphQueues[id]
+}
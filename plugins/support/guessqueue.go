@@ -0,0 +1,255 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/types"
+)
+
+const (
+	// guessThresholdOption caps, in entries, how much of the guess backlog
+	// stays in memory before the queue spills to disk.
+	guessThresholdOption  = "guess/memory_threshold"
+	defaultGuessThreshold = 100000
+	// segmentEntries is how many guesses one on-disk segment holds.
+	segmentEntries = 50000
+)
+
+// GuessQueue buffers FQDN guess candidates. Small backlogs stay in memory;
+// once the threshold is crossed, overflow goes to append-only gzip segments
+// on disk read back in batches, so alteration storms of tens of millions of
+// candidates do not require proportional RAM.
+type GuessQueue struct {
+	lock      sync.Mutex
+	dir       string
+	threshold int
+
+	mem      []string
+	segments []string
+	segNum   int
+
+	// The open segment accepting appends.
+	seg     *os.File
+	zw      *gzip.Writer
+	written int
+}
+
+// NewGuessQueue creates a guess queue spilling into the session work dir.
+func NewGuessQueue(session types.Session) (*GuessQueue, error) {
+	dir, err := EngineWorkDir(session.ID().String(), "guessqueue")
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := OptionInt(session.Config(), guessThresholdOption, defaultGuessThreshold)
+	if threshold <= 0 {
+		threshold = defaultGuessThreshold
+	}
+	return &GuessQueue{dir: dir, threshold: threshold}, nil
+}
+
+// Enqueue adds one candidate to the queue.
+func (q *GuessQueue) Enqueue(name string) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.mem) < q.threshold && q.seg == nil {
+		q.mem = append(q.mem, name)
+		return nil
+	}
+	return q.appendToSegment(name)
+}
+
+// appendToSegment writes the candidate to the open segment, rotating when
+// the segment is full. The caller must hold the lock.
+func (q *GuessQueue) appendToSegment(name string) error {
+	if q.seg == nil {
+		path := filepath.Join(q.dir, fmt.Sprintf("segment-%08d.gz", q.segNum))
+		q.segNum++
+
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+		if err != nil {
+			return err
+		}
+		q.seg = f
+		q.zw = gzip.NewWriter(f)
+		q.segments = append(q.segments, path)
+		q.written = 0
+	}
+
+	if _, err := q.zw.Write([]byte(name + "\n")); err != nil {
+		return err
+	}
+
+	if q.written++; q.written >= segmentEntries {
+		return q.closeSegment()
+	}
+	return nil
+}
+
+// closeSegment flushes and closes the open segment. The caller must hold
+// the lock.
+func (q *GuessQueue) closeSegment() error {
+	if q.seg == nil {
+		return nil
+	}
+
+	err := q.zw.Close()
+	if cerr := q.seg.Close(); err == nil {
+		err = cerr
+	}
+	q.seg, q.zw = nil, nil
+	return err
+}
+
+// Dequeue removes and returns up to max candidates, refilling the memory
+// buffer one whole segment at a time so disk reads stay batched.
+func (q *GuessQueue) Dequeue(max int) ([]string, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.mem) == 0 && len(q.segments) > 0 {
+		if err := q.loadSegment(); err != nil {
+			return nil, err
+		}
+	}
+
+	n := max
+	if n > len(q.mem) {
+		n = len(q.mem)
+	}
+	results := q.mem[:n]
+	q.mem = q.mem[n:]
+	return results, nil
+}
+
+// loadSegment reads the oldest segment into the memory buffer and removes
+// it from disk. The caller must hold the lock.
+func (q *GuessQueue) loadSegment() error {
+	path := q.segments[0]
+	if q.seg != nil && len(q.segments) == 1 {
+		// The oldest segment is still open for appends.
+		if err := q.closeSegment(); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	scanner := bufio.NewScanner(zr)
+	for scanner.Scan() {
+		if name := scanner.Text(); name != "" {
+			q.mem = append(q.mem, name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	q.segments = q.segments[1:]
+	return os.Remove(path)
+}
+
+// Len returns the number of buffered candidates, counting full segments at
+// their capacity.
+func (q *GuessQueue) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	n := len(q.mem)
+	for range q.segments {
+		n += segmentEntries
+	}
+	if q.seg != nil {
+		n += q.written - segmentEntries
+	}
+	return n
+}
+
+// Close releases the queue and deletes any remaining segments.
+func (q *GuessQueue) Close() error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	err := q.closeSegment()
+	for _, path := range q.segments {
+		_ = os.Remove(path)
+	}
+	q.segments = nil
+	q.mem = nil
+	return err
+}
+
+// guessDrainBatch is how many queued candidates one drain pass dispatches.
+const guessDrainBatch = 1000
+
+// guessDrainInterval is how long the drainer idles on an empty queue.
+const guessDrainInterval = 100 * time.Millisecond
+
+var guessLock sync.Mutex
+var guessQueues = make(map[uuid.UUID]*GuessQueue)
+
+// sessionGuessQueue returns the session's guess queue, creating it and
+// starting its drain goroutine on first use.
+func sessionGuessQueue(s types.Session, d types.Dispatcher) *GuessQueue {
+	if s == nil || d == nil {
+		return nil
+	}
+
+	guessLock.Lock()
+	defer guessLock.Unlock()
+
+	if q, found := guessQueues[s.ID()]; found {
+		return q
+	}
+	q, err := NewGuessQueue(s)
+	if err != nil {
+		return nil
+	}
+	guessQueues[s.ID()] = q
+	go drainGuesses(s, d, q)
+	return q
+}
+
+// drainGuesses moves queued candidates into the pipelines in batches until
+// the session completes.
+func drainGuesses(s types.Session, d types.Dispatcher, q *GuessQueue) {
+	defer func() { _ = q.Close() }()
+
+	for !s.Done() {
+		entries, err := q.Dequeue(guessDrainBatch)
+		if err != nil || len(entries) == 0 {
+			time.Sleep(guessDrainInterval)
+			continue
+		}
+		for _, entry := range entries {
+			if s.Done() {
+				return
+			}
+			name, correlation, _ := strings.Cut(entry, "\t")
+			dispatchGuess(s, d, name, correlation)
+		}
+	}
+}
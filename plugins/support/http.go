@@ -0,0 +1,110 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MaxBodySize limits how much of a response body is read into memory.
+const MaxBodySize int64 = 5 * 1024 * 1024
+
+// WebResponse captures the parts of an HTTP response the plugins care about,
+// including the redirect chain that led to the final URL.
+type WebResponse struct {
+	URL        string
+	StatusCode int
+	Header     http.Header
+	Body       string
+	Chain      []string
+}
+
+var webClient = &http.Client{Timeout: 30 * time.Second}
+
+var httpSlotLock sync.Mutex
+var httpSlots chan struct{}
+
+// SetHTTPMaxConcurrency caps how many fetches run at once from session
+// configuration, so the profiles can keep the engine's web footprint small.
+// Zero or negative removes the cap.
+func SetHTTPMaxConcurrency(limit int) {
+	httpSlotLock.Lock()
+	defer httpSlotLock.Unlock()
+
+	if limit > 0 {
+		httpSlots = make(chan struct{}, limit)
+	} else {
+		httpSlots = nil
+	}
+}
+
+// acquireHTTPSlot blocks until a fetch slot is free and returns its release.
+func acquireHTTPSlot() func() {
+	httpSlotLock.Lock()
+	slots := httpSlots
+	httpSlotLock.Unlock()
+
+	if slots == nil {
+		return func() {}
+	}
+	slots <- struct{}{}
+	return func() { <-slots }
+}
+
+// FetchURL performs a GET request for the provided URL, following redirects
+// while recording each hop in the returned chain.
+func FetchURL(ctx context.Context, url string) (*WebResponse, error) {
+	if EmergencyStopped() {
+		return nil, EngineContext().Err()
+	}
+	if err := MaybeInjectFault(faultCfg, "http"); err != nil {
+		return nil, err
+	}
+	release := acquireHTTPSlot()
+	defer release()
+	countOutbound()
+
+	var chain []string
+
+	client := *webClient
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return http.ErrUseLastResponse
+		}
+		chain = append(chain, req.URL.String())
+		return nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyCompliance(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxBodySize))
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebResponse{
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       string(body),
+		Chain:      chain,
+	}, nil
+}
@@ -0,0 +1,45 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+var idnProfile = idna.New(
+	idna.MapForLookup(),
+	idna.StrictDomainName(true),
+	idna.Transitional(false),
+)
+
+// ALabel converts the provided domain name to its lowercase ASCII (A-label)
+// representation. Names that are already ASCII are returned lowercased.
+func ALabel(name string) (string, error) {
+	return idnProfile.ToASCII(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// ULabel converts the provided domain name to its Unicode (U-label)
+// representation.
+func ULabel(name string) (string, error) {
+	return idnProfile.ToUnicode(strings.ToLower(strings.TrimSpace(name)))
+}
+
+// IsIDN returns true when the name contains at least one internationalized
+// label, in either its A-label or U-label form.
+func IsIDN(name string) bool {
+	for _, label := range strings.Split(name, ".") {
+		if strings.HasPrefix(label, "xn--") {
+			return true
+		}
+		for _, r := range label {
+			if r > 127 {
+				return true
+			}
+		}
+	}
+	return false
+}
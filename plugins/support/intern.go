@@ -0,0 +1,54 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// Large enumerations pass millions of domain names through a session, and
+// most of them repeat. Interning returns one shared copy of each distinct
+// name so events, caches, and the session stores all reference the same
+// backing memory. The tables are kept per session and freed with it, so
+// the engine's footprint never grows with sessions it has already run.
+var internLock sync.Mutex
+var interned = make(map[uuid.UUID]map[string]string)
+
+// InternFQDN lowercases and trims the name, and returns the session's
+// canonical shared copy of it.
+func InternFQDN(id uuid.UUID, name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return name
+	}
+
+	internLock.Lock()
+	defer internLock.Unlock()
+
+	table, found := interned[id]
+	if !found {
+		table = make(map[string]string)
+		interned[id] = table
+	}
+	if c, found := table[name]; found {
+		return c
+	}
+	// strings.Clone detaches the key from any larger buffer the argument
+	// may be a slice of, so the table never pins parent allocations.
+	c := strings.Clone(name)
+	table[c] = c
+	return c
+}
+
+// InternedCount returns the number of distinct names held for the session,
+// exposed for memory reporting.
+func InternedCount(id uuid.UUID) int {
+	internLock.Lock()
+	defer internLock.Unlock()
+	return len(interned[id])
+}
@@ -0,0 +1,45 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestInternFQDN(t *testing.T) {
+	id := uuid.New()
+	defer CleanupSession(id)
+
+	if name := InternFQDN(id, "  WWW.Example.COM "); name != "www.example.com" {
+		t.Errorf("expected the name normalized, got %q", name)
+	}
+	InternFQDN(id, "www.example.com")
+	InternFQDN(id, "mail.example.com")
+
+	if count := InternedCount(id); count != 2 {
+		t.Errorf("expected 2 distinct names, got %d", count)
+	}
+}
+
+func TestInternFQDNPerSession(t *testing.T) {
+	first := uuid.New()
+	second := uuid.New()
+	defer CleanupSession(second)
+
+	InternFQDN(first, "www.example.com")
+	InternFQDN(second, "www.example.com")
+	InternFQDN(second, "mail.example.com")
+
+	CleanupSession(first)
+
+	if count := InternedCount(first); count != 0 {
+		t.Errorf("expected the cleaned session's table freed, got %d names", count)
+	}
+	if count := InternedCount(second); count != 2 {
+		t.Errorf("expected the other session untouched, got %d names", count)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// journalOption enables the append-only event journal for a session.
+const journalOption = "session/journal"
+
+// JournalEntry is one dispatched event as recorded for replay.
+type JournalEntry struct {
+	Name       string    `json:"name"`
+	AssetType  string    `json:"asset_type"`
+	Timestamp  time.Time `json:"timestamp"`
+	Provenance string    `json:"provenance,omitempty"`
+	Source     string    `json:"source,omitempty"`
+}
+
+var journalLock sync.Mutex
+var journals = make(map[uuid.UUID]*os.File)
+
+// JournalEvent appends the event to the session's journal when journaling
+// is enabled. Replayed against a newer engine build, the journal reproduces
+// the event stream without re-contacting any data source, with the HTTP
+// and DNS caches supplying response data.
+func JournalEvent(e *types.Event) {
+	if e == nil || e.Session == nil || !OptionEnabled(e.Session.Config(), journalOption) {
+		return
+	}
+
+	journalLock.Lock()
+	defer journalLock.Unlock()
+
+	id := e.Session.ID()
+	f, found := journals[id]
+	if !found {
+		dir, err := EngineWorkDir(id.String())
+		if err != nil {
+			return
+		}
+
+		f, err = os.OpenFile(filepath.Join(dir, "journal.jsonl"),
+			os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+		if err != nil {
+			return
+		}
+		journals[id] = f
+	}
+
+	entry := &JournalEntry{
+		Name:      e.Name,
+		AssetType: string(e.Asset.Asset.AssetType()),
+		Timestamp: e.Timestamp,
+	}
+	if prov := AssetProvenance(id, e.Name); prov != nil {
+		entry.Provenance = prov.Class
+		entry.Source = prov.Source
+	}
+
+	if data, err := json.Marshal(entry); err == nil {
+		_, _ = f.Write(append(data, '\n'))
+	}
+}
+
+// CloseJournal releases the session's journal file.
+func CloseJournal(id uuid.UUID) {
+	journalLock.Lock()
+	defer journalLock.Unlock()
+
+	if f, found := journals[id]; found {
+		_ = f.Close()
+		delete(journals, id)
+	}
+}
+
+// ReplayJournal reads a recorded journal and re-dispatches its events into
+// the provided session in their original order, enabling deterministic
+// replays when testing plugin changes.
+func ReplayJournal(session types.Session, d types.Dispatcher, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		// Only FQDN events are reconstructed today; the other asset types
+		// reappear as their handlers re-derive them from these.
+		if entry.AssetType != string(oam.FQDN) {
+			continue
+		}
+
+		a, err := session.Cache().Create(nil, "", &oamdns.FQDN{Name: entry.Name})
+		if err != nil || a == nil {
+			continue
+		}
+
+		e := types.AcquireEvent()
+		e.Name = entry.Name
+		e.Asset = a
+		e.Timestamp = time.Now()
+		e.Session = session
+		e.Dispatcher = d
+		_ = d.DispatchEvent(e)
+	}
+	return scanner.Err()
+}
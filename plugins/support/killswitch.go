@@ -0,0 +1,83 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// killSwitch carries the engine-wide emergency stop state. Every network
+// operation derives its context from EngineContext so that triggering the
+// switch immediately cancels in-flight resolver queries, HTTP requests,
+// and pipeline work across all sessions, while collected data is preserved.
+type killSwitch struct {
+	sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+var stopSwitch = newKillSwitch()
+
+func newKillSwitch() *killSwitch {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &killSwitch{ctx: ctx, cancel: cancel}
+}
+
+// EngineContext returns the context all network operations should derive
+// from; it is cancelled by TriggerEmergencyStop.
+func EngineContext() context.Context {
+	stopSwitch.Lock()
+	defer stopSwitch.Unlock()
+	return stopSwitch.ctx
+}
+
+// TriggerEmergencyStop immediately halts all active network operations
+// across every session by cancelling the engine context.
+func TriggerEmergencyStop(reason string) {
+	stopSwitch.Lock()
+	defer stopSwitch.Unlock()
+
+	slog.Warn("emergency stop triggered", "reason", reason)
+	stopSwitch.cancel()
+}
+
+// EmergencyStopped returns true once the kill switch has been triggered.
+func EmergencyStopped() bool {
+	select {
+	case <-EngineContext().Done():
+		return true
+	default:
+		return false
+	}
+}
+
+// ResetEmergencyStop re-arms the kill switch, allowing network operations
+// to resume for newly started work.
+func ResetEmergencyStop() {
+	stopSwitch.Lock()
+	defer stopSwitch.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopSwitch.ctx = ctx
+	stopSwitch.cancel = cancel
+}
+
+// WatchStopSignal triggers the emergency stop when SIGUSR1 is received,
+// giving operators an out-of-band kill switch alongside the API mutation.
+func WatchStopSignal() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR1)
+
+	go func() {
+		for range ch {
+			TriggerEmergencyStop("stop signal received")
+		}
+	}()
+}
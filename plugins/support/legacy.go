@@ -0,0 +1,130 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/owasp-amass/config/config"
+)
+
+// legacyFileOption points at an Amass v3 data-source configuration file
+// whose credentials are overlaid onto the session's data sources.
+const legacyFileOption = "datasources/legacy_file"
+
+var legacyLock sync.Mutex
+var legacyCreds = make(map[string]*config.Credentials)
+var legacyLoaded = make(map[string]struct{})
+
+// LoadLegacyDataSources reads a legacy Amass v3 data-source configuration,
+// in either the INI or the simple YAML layout, and overlays the credentials
+// found there onto credential lookups. It returns how many sources carried
+// credentials, easing migration for existing users without a rewrite of
+// their configuration files.
+func LoadLegacyDataSources(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	legacyLock.Lock()
+	defer legacyLock.Unlock()
+
+	var count int
+	var source string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		// INI layout: [data_sources.Shodan] or [data_sources.Shodan.Credentials].
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section := strings.Trim(line, "[]")
+			if rest, found := strings.CutPrefix(section, "data_sources."); found {
+				source, _, _ = strings.Cut(rest, ".")
+				source = strings.ToLower(source)
+			} else {
+				source = ""
+			}
+			continue
+		}
+
+		// YAML layout: "- name: Shodan" opens a source entry.
+		if rest, found := strings.CutPrefix(line, "- name:"); found {
+			source = strings.ToLower(strings.TrimSpace(rest))
+			continue
+		}
+
+		if source == "" {
+			continue
+		}
+		key, value, found := legacyKeyValue(line)
+		if !found || value == "" {
+			continue
+		}
+
+		cr, known := legacyCreds[source]
+		if !known {
+			cr = &config.Credentials{Name: source}
+			legacyCreds[source] = cr
+			count++
+		}
+		switch key {
+		case "apikey":
+			cr.Apikey = value
+		case "secret":
+			cr.Secret = value
+		case "username":
+			cr.Username = value
+		case "password":
+			cr.Password = value
+		}
+	}
+	return count, scanner.Err()
+}
+
+// legacyKeyValue splits one credential line in either "key = value" INI
+// form or "key: value" YAML form.
+func legacyKeyValue(line string) (string, string, bool) {
+	sep := "="
+	if !strings.Contains(line, "=") {
+		sep = ":"
+	}
+	key, value, found := strings.Cut(line, sep)
+	if !found {
+		return "", "", false
+	}
+
+	key = strings.ToLower(strings.TrimSpace(key))
+	value = strings.Trim(strings.TrimSpace(value), `"'`)
+	return key, value, true
+}
+
+// legacyDataSourceCreds consults the overlay loaded from a legacy file,
+// loading the configured file on first use.
+func legacyDataSourceCreds(cfg *config.Config, name string) *config.Credentials {
+	if path := OptionString(cfg, legacyFileOption, ""); path != "" {
+		legacyLock.Lock()
+		_, loaded := legacyLoaded[path]
+		if !loaded {
+			legacyLoaded[path] = struct{}{}
+		}
+		legacyLock.Unlock()
+
+		if !loaded {
+			_, _ = LoadLegacyDataSources(path)
+		}
+	}
+
+	legacyLock.Lock()
+	defer legacyLock.Unlock()
+	return legacyCreds[strings.ToLower(name)]
+}
@@ -0,0 +1,132 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owasp-amass/config/config"
+)
+
+// negCacheTTLOption sets how many hours a negative DNS result is remembered
+// across sessions targeting the same zone.
+const negCacheTTLOption = "dns/negative_cache_ttl"
+
+// defaultNegCacheTTL is the default negative-result retention.
+const defaultNegCacheTTL = 72 * time.Hour
+
+// negCache is the persistent negative-result cache consulted before guess
+// names are retried, shared across sessions through files kept per zone.
+type negCache struct {
+	sync.Mutex
+	loaded map[string]map[string]time.Time
+}
+
+var negatives = &negCache{loaded: make(map[string]map[string]time.Time)}
+
+// NegativeCached returns true when the name failed to resolve recently
+// enough that it should not be retried.
+func NegativeCached(cfg *config.Config, name string) bool {
+	zone := RegisteredDomain(cfg, name)
+	if zone == "" {
+		return false
+	}
+
+	negatives.Lock()
+	defer negatives.Unlock()
+
+	entries, err := negatives.zoneEntries(zone)
+	if err != nil {
+		return false
+	}
+
+	when, found := entries[name]
+	return found && time.Since(when) < negCacheTTL(cfg)
+}
+
+// AddNegative records that the name failed to resolve, persisting the entry
+// for future sessions.
+func AddNegative(cfg *config.Config, name string) {
+	zone := RegisteredDomain(cfg, name)
+	if zone == "" {
+		return
+	}
+
+	negatives.Lock()
+	defer negatives.Unlock()
+
+	entries, err := negatives.zoneEntries(zone)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	entries[name] = now
+
+	path, err := negCachePath(zone)
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+
+	_, _ = f.WriteString(name + " " + strconv.FormatInt(now.Unix(), 10) + "\n")
+}
+
+// zoneEntries loads the persisted entries for the zone on first use.
+func (nc *negCache) zoneEntries(zone string) (map[string]time.Time, error) {
+	if entries, found := nc.loaded[zone]; found {
+		return entries, nil
+	}
+
+	entries := make(map[string]time.Time)
+	nc.loaded[zone] = entries
+
+	path, err := negCachePath(zone)
+	if err != nil {
+		return entries, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return entries, nil
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		if secs, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			entries[fields[0]] = time.Unix(secs, 0)
+		}
+	}
+	return entries, nil
+}
+
+func negCachePath(zone string) (string, error) {
+	dir, err := EngineCacheDir("negcache")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, zone), nil
+}
+
+func negCacheTTL(cfg *config.Config) time.Duration {
+	if hours := OptionInt(cfg, negCacheTTLOption, 0); hours > 0 {
+		return time.Duration(hours) * time.Hour
+	}
+	return defaultNegCacheTTL
+}
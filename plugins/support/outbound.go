@@ -0,0 +1,21 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import "sync/atomic"
+
+// outbound counts every network request the engine sends, across both the
+// HTTP client and the DNS resolver layer. Budget enforcement compares
+// snapshots of this counter around handler executions.
+var outbound atomic.Int64
+
+func countOutbound() {
+	outbound.Add(1)
+}
+
+// OutboundRequests returns the total outbound requests sent by the engine.
+func OutboundRequests() int64 {
+	return outbound.Load()
+}
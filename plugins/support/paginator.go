@@ -0,0 +1,87 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrStopPagination is returned by a PageFunc to end pagination cleanly,
+// such as when a response indicates the final page was reached.
+var ErrStopPagination = errors.New("pagination stopped by the page function")
+
+// PageFunc fetches one page. The cursor is the opaque token or offset for
+// the page being requested; it returns the cursor for the next page, or the
+// empty string when no pages remain.
+type PageFunc func(ctx context.Context, cursor string) (string, error)
+
+// Paginator drives page-by-page retrieval for API plugins, handling
+// cursor/offset advancement, per-page rate limiting, partial-failure
+// resumption, and maximum page budgets.
+type Paginator struct {
+	// MaxPages caps the number of pages fetched; zero means no budget.
+	MaxPages int
+	// Delay is the minimum time between page requests.
+	Delay time.Duration
+	// MaxRetries is how many times a failing page is retried before the
+	// pagination gives up and reports the cursor it stopped at.
+	MaxRetries int
+	// FirstCursor is the token or offset for the initial page, allowing a
+	// previously interrupted pagination to be resumed.
+	FirstCursor string
+}
+
+// Run fetches pages until the page function reports no next cursor, the page
+// budget is spent, or the context is cancelled. It returns the cursor that
+// should be used to resume when pagination ended early, along with any error
+// that stopped it.
+func (p *Paginator) Run(ctx context.Context, fn PageFunc) (string, error) {
+	cursor := p.FirstCursor
+
+	for page := 0; p.MaxPages == 0 || page < p.MaxPages; page++ {
+		if page > 0 && p.Delay > 0 {
+			t := time.NewTimer(p.Delay)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return cursor, ctx.Err()
+			case <-t.C:
+			}
+		}
+
+		next, err := p.fetch(ctx, fn, cursor)
+		if errors.Is(err, ErrStopPagination) {
+			return "", nil
+		}
+		if err != nil {
+			return cursor, err
+		}
+		if next == "" {
+			return "", nil
+		}
+		cursor = next
+	}
+	return cursor, nil
+}
+
+// fetch executes the page function with retries for transient failures.
+func (p *Paginator) fetch(ctx context.Context, fn PageFunc, cursor string) (string, error) {
+	var err error
+	var next string
+
+	for attempt := 0; attempt <= p.MaxRetries; attempt++ {
+		if err = ctx.Err(); err != nil {
+			break
+		}
+
+		next, err = fn(ctx, cursor)
+		if err == nil || errors.Is(err, ErrStopPagination) {
+			break
+		}
+	}
+	return next, err
+}
@@ -0,0 +1,54 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Environment variables overriding the default engine directories, so
+// containerized deployments can point all persistent state at a mounted
+// volume without relying on HOME or other interactive assumptions.
+const (
+	CacheDirEnv = "AMASS_ENGINE_CACHE_DIR"
+	WorkDirEnv  = "AMASS_ENGINE_WORK_DIR"
+)
+
+// EngineCacheDir returns the directory holding persistent engine state such
+// as negative caches and suppression lists. The environment override is
+// honored first; otherwise the platform cache directory is used, falling
+// back to the temp directory on systems without one (e.g. scratch
+// containers where no home directory exists).
+func EngineCacheDir(parts ...string) (string, error) {
+	base := os.Getenv(CacheDirEnv)
+	if base == "" {
+		var err error
+		if base, err = os.UserCacheDir(); err != nil {
+			base = os.TempDir()
+		}
+	}
+
+	dir := filepath.Join(append([]string{base, "amass-engine"}, parts...)...)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// EngineWorkDir returns the directory holding per-session scratch state
+// such as artifacts, honoring the environment override.
+func EngineWorkDir(parts ...string) (string, error) {
+	base := os.Getenv(WorkDirEnv)
+	if base == "" {
+		base = os.TempDir()
+	}
+
+	dir := filepath.Join(append([]string{base, "amass-engine"}, parts...)...)
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
@@ -0,0 +1,49 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/types"
+)
+
+// Property is a name/value annotation attached to an asset that does not
+// justify a node of its own on the graph.
+type Property struct {
+	Name  string
+	Value string
+}
+
+var propLock sync.Mutex
+var properties = make(map[uuid.UUID]map[string][]*Property)
+
+// AddProperty attaches the name/value pair to the identified asset within
+// the session, making it queryable through the API.
+func AddProperty(session types.Session, asset, name, value string) {
+	if session == nil || asset == "" || name == "" {
+		return
+	}
+
+	propLock.Lock()
+	defer propLock.Unlock()
+
+	id := session.ID()
+	if _, found := properties[id]; !found {
+		properties[id] = make(map[string][]*Property)
+	}
+	properties[id][asset] = append(properties[id][asset], &Property{Name: name, Value: value})
+}
+
+// AssetProperties returns the properties attached to the identified asset.
+func AssetProperties(id uuid.UUID, asset string) []*Property {
+	propLock.Lock()
+	defer propLock.Unlock()
+
+	results := make([]*Property, len(properties[id][asset]))
+	copy(results, properties[id][asset])
+	return results
+}
@@ -0,0 +1,75 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/types"
+)
+
+// Provenance classes ordered from least to most trustworthy.
+const (
+	ProvenanceGuess     = "guess"
+	ProvenanceScraped   = "scraped"
+	ProvenanceAPI       = "api"
+	ProvenanceConfirmed = "confirmed"
+)
+
+// provenanceRank orders the classes for threshold comparisons.
+var provenanceRank = map[string]int{
+	ProvenanceGuess:     0,
+	ProvenanceScraped:   1,
+	ProvenanceAPI:       2,
+	ProvenanceConfirmed: 3,
+}
+
+// Provenance records where an asset came from and how much it is trusted.
+type Provenance struct {
+	Source     string
+	Class      string
+	Confidence int
+}
+
+var provLock sync.Mutex
+var provenance = make(map[uuid.UUID]map[string]*Provenance)
+
+// SetProvenance records the origin of the named asset. Later recordings
+// only replace an earlier one when they raise the trust level.
+func SetProvenance(session types.Session, asset string, p *Provenance) {
+	if session == nil || asset == "" || p == nil {
+		return
+	}
+
+	provLock.Lock()
+	defer provLock.Unlock()
+
+	id := session.ID()
+	if _, found := provenance[id]; !found {
+		provenance[id] = make(map[string]*Provenance)
+	}
+	if cur, found := provenance[id][asset]; found {
+		if provenanceRank[cur.Class] > provenanceRank[p.Class] ||
+			(cur.Class == p.Class && cur.Confidence >= p.Confidence) {
+			return
+		}
+	}
+	provenance[id][asset] = p
+}
+
+// AssetProvenance returns the recorded origin of the named asset, or nil
+// when nothing was recorded.
+func AssetProvenance(id uuid.UUID, asset string) *Provenance {
+	provLock.Lock()
+	defer provLock.Unlock()
+	return provenance[id][asset]
+}
+
+// ProvenanceRank returns the numeric trust level for the provided class,
+// used when comparing against gating thresholds.
+func ProvenanceRank(class string) int {
+	return provenanceRank[class]
+}
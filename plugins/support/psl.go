@@ -0,0 +1,82 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"strings"
+
+	"github.com/owasp-amass/config/config"
+	"golang.org/x/net/publicsuffix"
+)
+
+// privateSuffixOption is the engine option holding additional private
+// suffixes (comma-separated) treated as effective TLDs for the session,
+// e.g. "cloudapp.azure.com,amazonaws.com".
+const privateSuffixOption = "scope/private_suffixes"
+
+// PrivateSuffixes returns the private suffixes configured for the session.
+func PrivateSuffixes(cfg *config.Config) []string {
+	var results []string
+
+	raw := OptionString(cfg, privateSuffixOption, "")
+	for _, s := range strings.Split(raw, ",") {
+		if s = strings.ToLower(strings.TrimSpace(s)); s != "" {
+			results = append(results, strings.Trim(s, "."))
+		}
+	}
+	return results
+}
+
+// EffectiveSuffix returns the effective TLD for the provided name, taking
+// both the public suffix list and session-configured private suffixes into
+// account. All wildcard checks and scope decisions should go through this
+// routine rather than consulting the PSL directly.
+func EffectiveSuffix(cfg *config.Config, name string) string {
+	name = strings.ToLower(strings.Trim(name, "."))
+
+	if suffix := matchPrivateSuffix(cfg, name); suffix != "" {
+		return suffix
+	}
+	suffix, _ := publicsuffix.PublicSuffix(name)
+	return suffix
+}
+
+// RegisteredDomain returns the effective TLD plus one label for the provided
+// name, honoring private suffixes, or the empty string when the name is an
+// effective TLD itself.
+func RegisteredDomain(cfg *config.Config, name string) string {
+	name = strings.ToLower(strings.Trim(name, "."))
+	suffix := EffectiveSuffix(cfg, name)
+
+	if name == suffix || suffix == "" {
+		return ""
+	}
+
+	rest := strings.TrimSuffix(name, "."+suffix)
+	labels := strings.Split(rest, ".")
+	return labels[len(labels)-1] + "." + suffix
+}
+
+// IsRegisteredDomain returns true when the provided name is exactly an
+// effective TLD plus one label, such as a zone apex on a cloud suffix.
+func IsRegisteredDomain(cfg *config.Config, name string) bool {
+	name = strings.ToLower(strings.Trim(name, "."))
+	return name != "" && RegisteredDomain(cfg, name) == name
+}
+
+// matchPrivateSuffix returns the longest configured private suffix that the
+// name falls under, or the empty string when none match.
+func matchPrivateSuffix(cfg *config.Config, name string) string {
+	var longest string
+
+	for _, suffix := range PrivateSuffixes(cfg) {
+		if name == suffix || strings.HasSuffix(name, "."+suffix) {
+			if len(suffix) > len(longest) {
+				longest = suffix
+			}
+		}
+	}
+	return longest
+}
@@ -0,0 +1,84 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/config/config"
+)
+
+// Sampling options. Once a zone has produced more guesses than the
+// threshold, only the configured percentage of further low-confidence
+// guesses is processed, trading exhaustiveness for representative coverage
+// of enormous scopes within a time budget.
+const (
+	samplingThresholdOption = "sampling/threshold"
+	samplingRateOption      = "sampling/rate"
+	defaultSamplingRate     = 10
+)
+
+// ZoneSampling reports how sampling treated one zone.
+type ZoneSampling struct {
+	Processed int64
+	Skipped   int64
+	Rate      int
+}
+
+var samplingLock sync.Mutex
+var sampling = make(map[uuid.UUID]map[string]*ZoneSampling)
+
+// SampleGuess decides whether a guess for the zone should be processed.
+// Below the threshold every guess passes; beyond it, passes are random at
+// the configured rate and the skips are counted for reporting.
+func SampleGuess(cfg *config.Config, id uuid.UUID, zone string) bool {
+	threshold := OptionInt(cfg, samplingThresholdOption, 0)
+	if threshold <= 0 || zone == "" {
+		return true
+	}
+
+	rate := OptionInt(cfg, samplingRateOption, defaultSamplingRate)
+	if rate <= 0 || rate > 100 {
+		rate = defaultSamplingRate
+	}
+
+	samplingLock.Lock()
+	defer samplingLock.Unlock()
+
+	if _, found := sampling[id]; !found {
+		sampling[id] = make(map[string]*ZoneSampling)
+	}
+	z, found := sampling[id][zone]
+	if !found {
+		z = &ZoneSampling{Rate: 100}
+		sampling[id][zone] = z
+	}
+
+	if z.Processed+z.Skipped >= int64(threshold) {
+		z.Rate = rate
+		if rand.Intn(100) >= rate {
+			z.Skipped++
+			return false
+		}
+	}
+
+	z.Processed++
+	return true
+}
+
+// SamplingReport returns the per-zone sampling counters for the session:
+// the effective rate and how many guesses were processed and skipped.
+func SamplingReport(id uuid.UUID) map[string]ZoneSampling {
+	samplingLock.Lock()
+	defer samplingLock.Unlock()
+
+	results := make(map[string]ZoneSampling, len(sampling[id]))
+	for zone, z := range sampling[id] {
+		results[zone] = *z
+	}
+	return results
+}
@@ -0,0 +1,114 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/types"
+)
+
+// autoApproveOption lists suffixes (comma-separated) for which proposed
+// scope candidates are approved without manual review.
+const autoApproveOption = "scope/auto_approve"
+
+// ScopeCandidate is a root domain or netblock proposed by a
+// horizontal-discovery plugin that has not yet entered active scope.
+type ScopeCandidate struct {
+	Value    string
+	Source   string
+	Reason   string
+	Proposed time.Time
+	Approved bool
+}
+
+var candLock sync.Mutex
+var candidates = make(map[uuid.UUID]map[string]*ScopeCandidate)
+
+// ProposeScopeCandidate queues a new root domain or netblock as a pending
+// scope candidate. Transforms do not run against it until it is approved
+// through the API or matched by an auto-approve rule.
+func ProposeScopeCandidate(session types.Session, value, source, reason string) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || session.Config().IsDomainInScope(value) {
+		return
+	}
+
+	candLock.Lock()
+	defer candLock.Unlock()
+
+	id := session.ID()
+	if _, found := candidates[id]; !found {
+		candidates[id] = make(map[string]*ScopeCandidate)
+	}
+	if _, found := candidates[id][value]; found {
+		return
+	}
+
+	c := &ScopeCandidate{
+		Value:    value,
+		Source:   source,
+		Reason:   reason,
+		Proposed: time.Now(),
+	}
+	candidates[id][value] = c
+
+	if autoApproved(session, value) {
+		c.Approved = true
+		session.Config().AddDomain(value)
+		session.Log().Info("scope candidate auto-approved", "value", value, "source", source)
+		return
+	}
+	session.Log().Info("scope candidate pending approval", "value", value, "source", source)
+}
+
+// ApproveScopeCandidate moves the pending candidate into active scope.
+func ApproveScopeCandidate(session types.Session, value string) bool {
+	candLock.Lock()
+	defer candLock.Unlock()
+
+	c, found := candidates[session.ID()][value]
+	if !found || c.Approved {
+		return false
+	}
+
+	c.Approved = true
+	session.Config().AddDomain(value)
+	return true
+}
+
+// PendingScopeCandidates returns the candidates awaiting approval.
+func PendingScopeCandidates(id uuid.UUID) []*ScopeCandidate {
+	candLock.Lock()
+	defer candLock.Unlock()
+
+	var results []*ScopeCandidate
+	for _, c := range candidates[id] {
+		if !c.Approved {
+			results = append(results, c)
+		}
+	}
+	return results
+}
+
+// autoApproved checks the candidate against the session's auto-approve
+// suffix rules.
+func autoApproved(session types.Session, value string) bool {
+	raw := OptionString(session.Config(), autoApproveOption, "")
+
+	for _, suffix := range strings.Split(raw, ",") {
+		suffix = strings.ToLower(strings.TrimSpace(suffix))
+		if suffix == "" {
+			continue
+		}
+		if value == suffix || strings.HasSuffix(value, "."+suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/types"
+)
+
+var statLock sync.Mutex
+var stats = make(map[uuid.UUID]map[string]map[string]int64)
+
+// IncrementStat adds the delta to the named per-plugin counter for the
+// session, creating the counter when first used.
+func IncrementStat(session types.Session, plugin, name string, delta int64) {
+	if session == nil {
+		return
+	}
+
+	statLock.Lock()
+	defer statLock.Unlock()
+
+	id := session.ID()
+	if _, found := stats[id]; !found {
+		stats[id] = make(map[string]map[string]int64)
+	}
+	if _, found := stats[id][plugin]; !found {
+		stats[id][plugin] = make(map[string]int64)
+	}
+	stats[id][plugin][name] += delta
+}
+
+// PluginStats returns a copy of the counters recorded for the plugin
+// within the identified session.
+func PluginStats(id uuid.UUID, plugin string) map[string]int64 {
+	statLock.Lock()
+	defer statLock.Unlock()
+
+	results := make(map[string]int64, len(stats[id][plugin]))
+	for name, value := range stats[id][plugin] {
+		results[name] = value
+	}
+	return results
+}
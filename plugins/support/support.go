@@ -0,0 +1,117 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/config/config"
+	"github.com/owasp-amass/engine/types"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+const (
+	// MaxHandlerInstances is the default number of concurrent handler executions.
+	MaxHandlerInstances int = 100
+)
+
+// bruteMaxGuessesOption caps how many guesses one session will accept in
+// total, bounding the work the brute-force and alteration stages generate.
+const bruteMaxGuessesOption = "brute/max_guesses"
+
+var guessBudgetLock sync.Mutex
+var guessBudgets = make(map[uuid.UUID]int)
+
+// withinGuessBudget counts the accepted guess against the session's budget
+// and reports whether the configured cap has been reached. Without a
+// configured cap every guess passes.
+func withinGuessBudget(cfg *config.Config, id uuid.UUID) bool {
+	max := OptionInt(cfg, bruteMaxGuessesOption, 0)
+
+	guessBudgetLock.Lock()
+	defer guessBudgetLock.Unlock()
+
+	if max > 0 && guessBudgets[id] >= max {
+		return false
+	}
+	guessBudgets[id]++
+	return true
+}
+
+// SubmitFQDNGuess dispatches the provided name as an unconfirmed FQDN guess
+// when the name falls within the session scope.
+func SubmitFQDNGuess(e *types.Event, name string) {
+	if e == nil || e.Session == nil {
+		return
+	}
+	if name = InternFQDN(e.Session.ID(), name); name == "" {
+		return
+	}
+	if err := ValidateHostname(name); err != nil {
+		RejectName("guess")
+		return
+	}
+	if !e.Session.Config().IsDomainInScope(name) {
+		return
+	}
+	// The Bloom filter drops the vast majority of duplicate guesses
+	// before they cost a cache or database round trip.
+	if KnownFQDN(e.Session.ID(), name) {
+		return
+	}
+	if NegativeCached(e.Session.Config(), name) {
+		return
+	}
+	cfg := e.Session.Config()
+	if !SampleGuess(cfg, e.Session.ID(), cfg.WhichDomain(name)) {
+		return
+	}
+	if !withinGuessBudget(cfg, e.Session.ID()) {
+		return
+	}
+
+	// Accepted guesses go through the session's disk-backed queue, so
+	// alteration storms are buffered rather than dispatched inline from
+	// whichever handler produced them.
+	if q := sessionGuessQueue(e.Session, e.Dispatcher); q != nil {
+		entry := name
+		if e.Correlation != "" {
+			entry += "\t" + e.Correlation
+		}
+		if err := q.Enqueue(entry); err == nil {
+			return
+		}
+	}
+	dispatchGuess(e.Session, e.Dispatcher, name, e.Correlation)
+}
+
+// dispatchGuess stores the guess in the session cache and hands it to the
+// dispatcher.
+func dispatchGuess(s types.Session, d types.Dispatcher, name, correlation string) {
+	if name == "" || d == nil {
+		return
+	}
+
+	fqdn := &oamdns.FQDN{Name: name}
+	if a, err := s.Cache().Create(nil, "", fqdn); err == nil && a != nil {
+		// Guesses enter the pipelines at the lowest trust class until a
+		// resolution or provider sighting upgrades them.
+		SetProvenance(s, name, &Provenance{
+			Source:     "guess",
+			Class:      ProvenanceGuess,
+			Confidence: 10,
+		})
+		guess := types.AcquireEvent()
+		guess.Name = name
+		guess.Asset = a
+		guess.Timestamp = time.Now()
+		guess.Session = s
+		guess.Dispatcher = d
+		guess.Correlation = correlation
+		_ = d.DispatchEvent(guess)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/owasp-amass/config/config"
+)
+
+// suppressionStore remembers assets marked as false positives per scope, so
+// later sessions covering the same zones automatically filter them from
+// results and exports while still recording them with a suppressed flag.
+type suppressionStore struct {
+	sync.Mutex
+	loaded map[string]map[string]struct{}
+}
+
+var suppressions = &suppressionStore{loaded: make(map[string]map[string]struct{})}
+
+// SuppressAsset marks the asset as a false positive for its scope and
+// persists the marking for future sessions.
+func SuppressAsset(cfg *config.Config, asset string) {
+	zone := RegisteredDomain(cfg, asset)
+	if zone == "" {
+		zone = "global"
+	}
+
+	suppressions.Lock()
+	defer suppressions.Unlock()
+
+	entries := suppressions.zoneEntries(zone)
+	if _, found := entries[asset]; found {
+		return
+	}
+	entries[asset] = struct{}{}
+
+	path, err := suppressionPath(zone)
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+	_, _ = f.WriteString(asset + "\n")
+}
+
+// IsSuppressed returns true when the asset was previously marked as a false
+// positive for its scope.
+func IsSuppressed(cfg *config.Config, asset string) bool {
+	zone := RegisteredDomain(cfg, asset)
+	if zone == "" {
+		zone = "global"
+	}
+
+	suppressions.Lock()
+	defer suppressions.Unlock()
+
+	_, found := suppressions.zoneEntries(zone)[asset]
+	return found
+}
+
+func (ss *suppressionStore) zoneEntries(zone string) map[string]struct{} {
+	if entries, found := ss.loaded[zone]; found {
+		return entries
+	}
+
+	entries := make(map[string]struct{})
+	ss.loaded[zone] = entries
+
+	path, err := suppressionPath(zone)
+	if err != nil {
+		return entries
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return entries
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if asset := strings.TrimSpace(scanner.Text()); asset != "" {
+			entries[asset] = struct{}{}
+		}
+	}
+	return entries
+}
+
+func suppressionPath(zone string) (string, error) {
+	dir, err := EngineCacheDir("suppressed")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, zone), nil
+}
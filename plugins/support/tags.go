@@ -0,0 +1,163 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/owasp-amass/config/config"
+)
+
+// tagStore persists analyst-applied asset tags per scope, so markings such
+// as "confirmed" or "false positive" survive engine restarts and reach
+// later sessions covering the same zones.
+type tagStore struct {
+	sync.Mutex
+	loaded map[string]map[string][]string
+}
+
+var tags = &tagStore{loaded: make(map[string]map[string][]string)}
+
+// AddTag applies the tag to the asset and persists the marking.
+func AddTag(cfg *config.Config, asset, tag string) {
+	if asset == "" || tag == "" {
+		return
+	}
+	zone := tagZone(cfg, asset)
+
+	tags.Lock()
+	defer tags.Unlock()
+
+	entries := tags.zoneEntries(zone)
+	for _, t := range entries[asset] {
+		if t == tag {
+			return
+		}
+	}
+	entries[asset] = append(entries[asset], tag)
+
+	path, err := tagPath(zone)
+	if err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0640)
+	if err != nil {
+		return
+	}
+	defer func() { _ = f.Close() }()
+	_, _ = f.WriteString(asset + "\t" + tag + "\n")
+}
+
+// RemoveTag takes the tag off the asset and rewrites the persisted state.
+func RemoveTag(cfg *config.Config, asset, tag string) {
+	zone := tagZone(cfg, asset)
+
+	tags.Lock()
+	defer tags.Unlock()
+
+	entries := tags.zoneEntries(zone)
+	var kept []string
+	for _, t := range entries[asset] {
+		if t != tag {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) == len(entries[asset]) {
+		return
+	}
+	if len(kept) > 0 {
+		entries[asset] = kept
+	} else {
+		delete(entries, asset)
+	}
+
+	path, err := tagPath(zone)
+	if err != nil {
+		return
+	}
+	var lines []string
+	for a, ts := range entries {
+		for _, t := range ts {
+			lines = append(lines, a+"\t"+t)
+		}
+	}
+	if len(lines) == 0 {
+		_ = os.Remove(path)
+		return
+	}
+	_ = os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0640)
+}
+
+// AssetTags returns the tags applied to the asset, allowing exports and
+// result filters to honor analyst markings.
+func AssetTags(cfg *config.Config, asset string) []string {
+	zone := tagZone(cfg, asset)
+
+	tags.Lock()
+	defer tags.Unlock()
+
+	entries := tags.zoneEntries(zone)
+	results := make([]string, len(entries[asset]))
+	copy(results, entries[asset])
+	return results
+}
+
+// HasTag returns true when the asset carries the provided tag.
+func HasTag(cfg *config.Config, asset, tag string) bool {
+	for _, t := range AssetTags(cfg, asset) {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (ts *tagStore) zoneEntries(zone string) map[string][]string {
+	if entries, found := ts.loaded[zone]; found {
+		return entries
+	}
+
+	entries := make(map[string][]string)
+	ts.loaded[zone] = entries
+
+	path, err := tagPath(zone)
+	if err != nil {
+		return entries
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return entries
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		asset, tag, found := strings.Cut(scanner.Text(), "\t")
+		if !found || asset == "" || tag == "" {
+			continue
+		}
+		entries[asset] = append(entries[asset], tag)
+	}
+	return entries
+}
+
+func tagZone(cfg *config.Config, asset string) string {
+	if zone := RegisteredDomain(cfg, asset); zone != "" {
+		return zone
+	}
+	return "global"
+}
+
+func tagPath(zone string) (string, error) {
+	dir, err := EngineCacheDir("tags")
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, zone), nil
+}
@@ -0,0 +1,175 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	oam "github.com/owasp-amass/open-asset-model"
+	"github.com/owasp-amass/open-asset-model/certificate"
+	"github.com/owasp-amass/open-asset-model/contact"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	oamnet "github.com/owasp-amass/open-asset-model/network"
+	oamurl "github.com/owasp-amass/open-asset-model/url"
+)
+
+// Interval is the observed validity window of an asset or relation:
+// recurring-monitoring sessions extend LastSeen on every sighting, so the
+// window answers both "when did this first appear" and "was this part of
+// the attack surface at time T".
+type Interval struct {
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// RelationInterval is one edge of the graph with its validity window.
+type RelationInterval struct {
+	Source   string
+	Relation string
+	Target   string
+	Interval
+}
+
+type sessionTimeline struct {
+	assets    map[string]*Interval
+	relations map[string]*RelationInterval
+}
+
+var timelineLock sync.Mutex
+var timelines = make(map[uuid.UUID]*sessionTimeline)
+
+// ObserveAsset records a sighting of the asset, opening its interval on
+// the first call and extending it on every later one.
+func ObserveAsset(id uuid.UUID, name string) {
+	if name == "" {
+		return
+	}
+
+	timelineLock.Lock()
+	defer timelineLock.Unlock()
+
+	t := sessionTimelines(id)
+	now := time.Now()
+	if iv, found := t.assets[name]; found {
+		iv.LastSeen = now
+		return
+	}
+	t.assets[name] = &Interval{FirstSeen: now, LastSeen: now}
+}
+
+// ObserveRelation records a sighting of the edge between two assets.
+func ObserveRelation(id uuid.UUID, src, relation, dst string) {
+	if src == "" || dst == "" {
+		return
+	}
+
+	timelineLock.Lock()
+	defer timelineLock.Unlock()
+
+	t := sessionTimelines(id)
+	now := time.Now()
+	key := src + "|" + relation + "|" + dst
+	if r, found := t.relations[key]; found {
+		r.LastSeen = now
+		return
+	}
+	t.relations[key] = &RelationInterval{
+		Source:   src,
+		Relation: relation,
+		Target:   dst,
+		Interval: Interval{FirstSeen: now, LastSeen: now},
+	}
+}
+
+// AssetInterval returns the validity window for the named asset, or nil
+// when the session never observed it.
+func AssetInterval(id uuid.UUID, name string) *Interval {
+	timelineLock.Lock()
+	defer timelineLock.Unlock()
+
+	if iv, found := sessionTimelines(id).assets[name]; found {
+		cp := *iv
+		return &cp
+	}
+	return nil
+}
+
+// AssetsAt returns the assets whose validity window contains the time.
+func AssetsAt(id uuid.UUID, at time.Time) []string {
+	timelineLock.Lock()
+	defer timelineLock.Unlock()
+
+	var results []string
+	for name, iv := range sessionTimelines(id).assets {
+		if !at.Before(iv.FirstSeen) && !at.After(iv.LastSeen) {
+			results = append(results, name)
+		}
+	}
+	return results
+}
+
+// RelationsAt returns the edges whose validity window contains the time.
+func RelationsAt(id uuid.UUID, at time.Time) []*RelationInterval {
+	timelineLock.Lock()
+	defer timelineLock.Unlock()
+
+	var results []*RelationInterval
+	for _, r := range sessionTimelines(id).relations {
+		if !at.Before(r.FirstSeen) && !at.After(r.LastSeen) {
+			cp := *r
+			results = append(results, &cp)
+		}
+	}
+	return results
+}
+
+// SessionRelations returns every edge the session has observed, with its
+// validity window.
+func SessionRelations(id uuid.UUID) []*RelationInterval {
+	timelineLock.Lock()
+	defer timelineLock.Unlock()
+
+	var results []*RelationInterval
+	for _, r := range sessionTimelines(id).relations {
+		cp := *r
+		results = append(results, &cp)
+	}
+	return results
+}
+
+// sessionTimelines returns the session's timeline, creating it on first
+// use. The caller must hold timelineLock.
+func sessionTimelines(id uuid.UUID) *sessionTimeline {
+	t, found := timelines[id]
+	if !found {
+		t = &sessionTimeline{
+			assets:    make(map[string]*Interval),
+			relations: make(map[string]*RelationInterval),
+		}
+		timelines[id] = t
+	}
+	return t
+}
+
+// assetKey renders the asset's identifying value for timeline bookkeeping.
+func assetKey(asset oam.Asset) string {
+	switch a := asset.(type) {
+	case *oamdns.FQDN:
+		return a.Name
+	case *oamnet.IPAddress:
+		return a.Address.String()
+	case *oamnet.Netblock:
+		return a.Cidr.String()
+	case *contact.EmailAddress:
+		return a.Address
+	case *oamurl.URL:
+		return a.Raw
+	case *certificate.TLSCertificate:
+		return a.SerialNumber
+	}
+	return ""
+}
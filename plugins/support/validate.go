@@ -0,0 +1,82 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/idna"
+)
+
+// Scraped pages and third-party APIs return plenty of garbage resembling
+// hostnames. Validation rejects malformed input before it becomes an event
+// or a database row, and the per-source counters make noisy sources easy
+// to identify.
+var rejectLock sync.Mutex
+var rejects = make(map[string]int64)
+
+// ValidateHostname reports whether the name is a well-formed hostname:
+// within length limits, no empty or overlong labels, only valid
+// characters, and punycode labels that actually decode.
+func ValidateHostname(name string) error {
+	if name == "" {
+		return errors.New("the name is empty")
+	}
+	if strings.HasPrefix(name, ".") || strings.HasSuffix(name, ".") {
+		return errors.New("the name has a leading or trailing dot")
+	}
+	if len(name) > 253 {
+		return errors.New("the name exceeds 253 characters")
+	}
+
+	for _, label := range strings.Split(name, ".") {
+		if label == "" {
+			return errors.New("the name contains an empty label")
+		}
+		if len(label) > 63 {
+			return errors.New("the name contains an overlong label")
+		}
+		if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return errors.New("a label begins or ends with a hyphen")
+		}
+		for _, r := range label {
+			switch {
+			case r >= 'a' && r <= 'z':
+			case r >= 'A' && r <= 'Z':
+			case r >= '0' && r <= '9':
+			case r == '-' || r == '_':
+			default:
+				return errors.New("the name contains an invalid character")
+			}
+		}
+		if strings.HasPrefix(strings.ToLower(label), "xn--") {
+			if _, err := idna.Lookup.ToUnicode(label); err != nil {
+				return errors.New("a punycode label fails to decode")
+			}
+		}
+	}
+	return nil
+}
+
+// RejectName counts one rejected input against the named source.
+func RejectName(source string) {
+	rejectLock.Lock()
+	defer rejectLock.Unlock()
+	rejects[source]++
+}
+
+// RejectCounts returns a snapshot of the rejected input counters by source.
+func RejectCounts() map[string]int64 {
+	rejectLock.Lock()
+	defer rejectLock.Unlock()
+
+	results := make(map[string]int64, len(rejects))
+	for source, count := range rejects {
+		results[source] = count
+	}
+	return results
+}
@@ -0,0 +1,83 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package support
+
+import (
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/config/config"
+	"github.com/owasp-amass/engine/types"
+)
+
+// Options constraining when active operations may run: a daily window such
+// as "22:00-06:00" and the IANA timezone it is expressed in.
+const (
+	engagementWindowOption   = "session/engagement_window"
+	engagementTimezoneOption = "session/engagement_timezone"
+)
+
+// InsideEngagementWindow returns true when active operations (brute force,
+// crawling, scanning) are currently allowed by the session's engagement
+// window. Sessions without a configured window are always inside it.
+func InsideEngagementWindow(cfg *config.Config) bool {
+	start, end, loc, ok := engagementWindow(cfg)
+	if !ok {
+		return true
+	}
+
+	now := time.Now().In(loc)
+	minutes := now.Hour()*60 + now.Minute()
+
+	if start <= end {
+		return minutes >= start && minutes < end
+	}
+	// The window wraps past midnight.
+	return minutes >= start || minutes < end
+}
+
+// HoldForEngagementWindow blocks until the engagement window opens or the
+// session ends, so out-of-window events are held rather than dropped. It
+// returns false when the session ended while waiting.
+func HoldForEngagementWindow(e *types.Event) bool {
+	for !InsideEngagementWindow(e.Session.Config()) {
+		if e.Session.Done() {
+			return false
+		}
+		time.Sleep(time.Minute)
+	}
+	return true
+}
+
+// engagementWindow parses the configured window into minutes-of-day bounds.
+func engagementWindow(cfg *config.Config) (int, int, *time.Location, bool) {
+	raw := OptionString(cfg, engagementWindowOption, "")
+	parts := strings.SplitN(raw, "-", 2)
+	if raw == "" || len(parts) != 2 {
+		return 0, 0, nil, false
+	}
+
+	start, serr := parseClock(parts[0])
+	end, eerr := parseClock(parts[1])
+	if serr != nil || eerr != nil {
+		return 0, 0, nil, false
+	}
+
+	loc := time.Local
+	if name := OptionString(cfg, engagementTimezoneOption, ""); name != "" {
+		if l, err := time.LoadLocation(name); err == nil {
+			loc = l
+		}
+	}
+	return start, end, loc, true
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
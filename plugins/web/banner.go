@@ -0,0 +1,173 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamnet "github.com/owasp-amass/open-asset-model/network"
+)
+
+// bannerGrabOption enables the non-HTTP banner-grab stage.
+const bannerGrabOption = "banner/enabled"
+
+// bannerPorts are the services probed for banners. Only server-talks-first
+// protocols are listed: the probe never writes to the connection, so it
+// cannot desynchronize or corrupt state in whatever is actually listening.
+var bannerPorts = map[int]string{
+	21:   "ftp",
+	22:   "ssh",
+	23:   "telnet",
+	25:   "smtp",
+	110:  "pop3",
+	143:  "imap",
+	3306: "mysql",
+	6379: "redis",
+}
+
+// notableServices raise a finding when found exposed on in-scope addresses.
+var notableServices = map[string]string{
+	"ftp":    "medium",
+	"telnet": "high",
+	"mysql":  "high",
+	"redis":  "high",
+}
+
+// bannerGrab probes confirmed addresses for common non-HTTP services and
+// records service and version hints from their greeting banners, feeding
+// notable exposures into the findings layer.
+type bannerGrab struct {
+	name string
+}
+
+// NewBannerGrab returns the non-HTTP service banner plugin.
+func NewBannerGrab() types.Plugin {
+	return &bannerGrab{name: "Banner-Grab"}
+}
+
+func (b *bannerGrab) Name() string {
+	return b.name
+}
+
+func (b *bannerGrab) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     b,
+		Name:       b.name + "-Handler",
+		Transforms: []string{"ipaddress"},
+		EventType:  oam.IPAddress,
+		Callback:   b.check,
+	})
+}
+
+func (b *bannerGrab) Stop() {}
+
+func (b *bannerGrab) check(e *types.Event) error {
+	ip, ok := e.Asset.Asset.(*oamnet.IPAddress)
+	if !ok {
+		return errors.New("failed to extract the IPAddress asset")
+	}
+	if !support.OptionEnabled(e.Session.Config(), bannerGrabOption) {
+		return nil
+	}
+	if !support.HoldForEngagementWindow(e) {
+		return nil
+	}
+
+	addr := ip.Address.String()
+	for port, service := range bannerPorts {
+		banner, read := grabBanner(addr, port)
+		support.RecordBandwidth(e.Session, b.name, 0, read)
+		if banner == "" {
+			continue
+		}
+		b.record(e, addr, port, service, banner)
+	}
+	return nil
+}
+
+// record stores the banner as properties and raises a finding for services
+// that should not be reachable from the collection vantage point.
+func (b *bannerGrab) record(e *types.Event, addr string, port int, service, banner string) {
+	label := fmt.Sprintf("%s/%d", service, port)
+
+	support.AddProperty(e.Session, addr, "service:banner", label+" "+banner)
+	if version := versionHint(service, banner); version != "" {
+		support.AddProperty(e.Session, addr, "service:version", label+" "+version)
+	}
+
+	severity, notable := notableServices[service]
+	if !notable {
+		return
+	}
+	support.AddFinding(e.Session, &support.Finding{
+		Type:        "service:exposure",
+		Asset:       addr,
+		Description: fmt.Sprintf("the %s service on port %d is exposed: %s", service, port, banner),
+		Severity:    severity,
+		Source:      b.name,
+	})
+}
+
+// grabBanner connects and reads whatever the service volunteers, without
+// ever sending bytes, and returns the sanitized first line along with how
+// many bytes were read. The dial is derived from the engine context, so the
+// kill switch also interrupts connections already underway.
+func grabBanner(addr string, port int) (string, int64) {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	conn, err := dialer.DialContext(support.EngineContext(), "tcp", fmt.Sprintf("%s:%d", addr, port))
+	if err != nil {
+		return "", 0
+	}
+	defer conn.Close()
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && line == "" {
+		return "", 0
+	}
+	read := int64(len(line))
+
+	line = strings.TrimSpace(line)
+	// Strip control characters from binary greetings, such as the MySQL
+	// handshake packet, keeping only the printable hints.
+	var printable strings.Builder
+	for _, r := range line {
+		if r >= 0x20 && r < 0x7f {
+			printable.WriteRune(r)
+		}
+	}
+	return printable.String(), read
+}
+
+// versionHint extracts the version portion of well-known banner formats.
+func versionHint(service, banner string) string {
+	switch service {
+	case "ssh":
+		// SSH-2.0-OpenSSH_9.3
+		if _, version, found := strings.Cut(banner, "SSH-2.0-"); found {
+			return version
+		}
+	case "ftp", "smtp", "pop3", "imap":
+		// 220 ProFTPD 1.3.8 Server ready
+		fields := strings.Fields(banner)
+		if len(fields) > 1 {
+			return strings.Join(fields[1:], " ")
+		}
+	case "mysql":
+		// The printable remainder of the handshake begins with the version.
+		if fields := strings.Fields(banner); len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamcert "github.com/owasp-amass/open-asset-model/certificate"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// certGraph models served TLS certificates as first-class graph nodes
+// related to the names presenting them, and detects the same certificate
+// reused across unexpected hosts — staging and production sharing a key,
+// or in-scope certs appearing on out-of-scope infrastructure.
+type certGraph struct {
+	name string
+
+	lock sync.Mutex
+	// fingerprint -> hosts observed serving it, per session.
+	served map[uuid.UUID]map[string][]string
+}
+
+// NewCertGraph returns the certificate relation and reuse plugin.
+func NewCertGraph() types.Plugin {
+	c := &certGraph{
+		name:   "Cert-Graph",
+		served: make(map[uuid.UUID]map[string][]string),
+	}
+
+	support.OnSessionCleanup(func(id uuid.UUID) {
+		c.lock.Lock()
+		delete(c.served, id)
+		c.lock.Unlock()
+	})
+	return c
+}
+
+func (c *certGraph) Name() string {
+	return c.name
+}
+
+func (c *certGraph) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     c,
+		Name:       c.name + "-Handler",
+		Transforms: []string{"tlscertificate"},
+		EventType:  oam.FQDN,
+		Callback:   c.check,
+	})
+}
+
+func (c *certGraph) Stop() {}
+
+func (c *certGraph) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+	if !e.Session.Config().IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+
+	cert := fetchCertificate(fqdn.Name)
+	if cert == nil {
+		return nil
+	}
+	support.RecordBandwidth(e.Session, c.name, 0, int64(len(cert.Raw)))
+
+	sum := sha256.Sum256(cert.Raw)
+	fingerprint := hex.EncodeToString(sum[:])
+
+	asset := &oamcert.TLSCertificate{
+		SerialNumber:      cert.SerialNumber.String(),
+		SubjectCommonName: cert.Subject.CommonName,
+		IssuerCommonName:  cert.Issuer.CommonName,
+		NotBefore:         cert.NotBefore.Format(time.RFC3339),
+		NotAfter:          cert.NotAfter.Format(time.RFC3339),
+	}
+	if a, err := support.Graph(e.Session).Create(e.Asset, "certificate", asset); err != nil || a == nil {
+		return nil
+	}
+	support.AddProperty(e.Session, fqdn.Name, "cert:fingerprint", fingerprint)
+
+	// The SAN list often holds additional in-scope names.
+	for _, san := range cert.DNSNames {
+		support.SubmitFQDNGuess(e, strings.TrimPrefix(san, "*."))
+	}
+
+	c.detectReuse(e, fqdn.Name, fingerprint)
+	return nil
+}
+
+// detectReuse records the serving host against the certificate fingerprint
+// and raises a finding once the same certificate shows up elsewhere.
+func (c *certGraph) detectReuse(e *types.Event, host, fingerprint string) {
+	c.lock.Lock()
+	id := e.Session.ID()
+	if _, found := c.served[id]; !found {
+		c.served[id] = make(map[string][]string)
+	}
+	hosts := c.served[id][fingerprint]
+	for _, h := range hosts {
+		if h == host {
+			c.lock.Unlock()
+			return
+		}
+	}
+	c.served[id][fingerprint] = append(hosts, host)
+	c.lock.Unlock()
+
+	if len(hosts) == 0 {
+		return
+	}
+
+	severity := "info"
+	if !e.Session.Config().IsDomainInScope(hosts[0]) || !e.Session.Config().IsDomainInScope(host) {
+		severity = "medium"
+	}
+	support.AddFinding(e.Session, &support.Finding{
+		Type:        "cert:reuse",
+		Asset:       host,
+		Description: "the certificate " + fingerprint[:16] + " is also served by " + strings.Join(hosts, ", "),
+		Severity:    severity,
+		Source:      c.name,
+	})
+}
+
+// fetchCertificate returns the leaf certificate served on the host's
+// HTTPS port, or nil when the handshake fails.
+func fetchCertificate(host string) *x509.Certificate {
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+
+	conn, err := tls.DialWithDialer(dialer, "tcp", host+":443", &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil
+	}
+	defer conn.Close()
+
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil
+	}
+	return certs[0]
+}
@@ -0,0 +1,91 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+// Cluster groups near-identical web services, such as load-balanced
+// frontends, parked pages, and default vhosts, so large result sets can be
+// triaged efficiently.
+type Cluster struct {
+	Hash  string
+	Title string
+	Hosts []string
+}
+
+var clusterLock sync.Mutex
+var clusters = make(map[uuid.UUID]map[string]*Cluster)
+
+func init() {
+	support.OnSessionCleanup(func(id uuid.UUID) {
+		clusterLock.Lock()
+		delete(clusters, id)
+		clusterLock.Unlock()
+	})
+}
+
+var titleRE = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+var digitsRE = regexp.MustCompile(`\d+`)
+
+// recordContent hashes the response content and files the host under the
+// matching cluster for the session.
+func recordContent(id uuid.UUID, host, body string) {
+	title := pageTitle(body)
+	hash := contentHash(title, body)
+
+	clusterLock.Lock()
+	defer clusterLock.Unlock()
+
+	if _, found := clusters[id]; !found {
+		clusters[id] = make(map[string]*Cluster)
+	}
+	c, found := clusters[id][hash]
+	if !found {
+		c = &Cluster{Hash: hash, Title: title}
+		clusters[id][hash] = c
+	}
+	c.Hosts = append(c.Hosts, host)
+}
+
+// SessionClusters returns the content clusters built for the session.
+func SessionClusters(id uuid.UUID) []*Cluster {
+	clusterLock.Lock()
+	defer clusterLock.Unlock()
+
+	var results []*Cluster
+	for _, c := range clusters[id] {
+		results = append(results, c)
+	}
+	return results
+}
+
+// contentHash produces a fuzzy hash of the title and a normalized form of
+// the body so trivially dynamic content lands in the same cluster.
+func contentHash(title, body string) string {
+	norm := strings.ToLower(body)
+	norm = digitsRE.ReplaceAllString(norm, "0")
+	norm = strings.Join(strings.Fields(norm), " ")
+
+	h := sha256.New()
+	_, _ = h.Write([]byte(title))
+	_, _ = h.Write([]byte(norm))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func pageTitle(body string) string {
+	if m := titleRE.FindStringSubmatch(body); len(m) == 2 {
+		return strings.TrimSpace(m[1])
+	}
+	return ""
+}
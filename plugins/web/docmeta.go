@@ -0,0 +1,155 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	"github.com/owasp-amass/open-asset-model/contact"
+	oamurl "github.com/owasp-amass/open-asset-model/url"
+)
+
+// docExtensions are the document types fetched for metadata mining.
+var docExtensions = []string{".pdf", ".docx", ".xlsx", ".pptx"}
+
+var pdfInfoRE = regexp.MustCompile(`/(Author|Creator|Producer|Title)\s*\(([^)]{1,256})\)`)
+var xmlTagRE = regexp.MustCompile(`<(?:dc:creator|cp:lastModifiedBy|dc:title)>([^<]{1,256})<`)
+var pathRE = regexp.MustCompile(`(?:[A-Za-z]:\\|\\\\|/home/|/Users/)[\w .$~\\/-]{3,200}`)
+
+// docMeta fetches in-scope documents discovered through crawling or search
+// and extracts hostnames, usernames, email addresses, and internal paths
+// from their metadata, feeding results into the graph with document
+// provenance.
+type docMeta struct {
+	name string
+}
+
+// NewDocMeta returns the document metadata mining plugin.
+func NewDocMeta() types.Plugin {
+	return &docMeta{name: "Web-DocMeta"}
+}
+
+func (d *docMeta) Name() string {
+	return d.name
+}
+
+func (d *docMeta) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     d,
+		Name:       d.name + "-Handler",
+		Transforms: []string{"fqdn", "emailaddress"},
+		EventType:  oam.URL,
+		Callback:   d.check,
+	})
+}
+
+func (d *docMeta) Stop() {}
+
+func (d *docMeta) check(e *types.Event) error {
+	u, ok := e.Asset.Asset.(*oamurl.URL)
+	if !ok {
+		return errors.New("failed to extract the URL asset")
+	}
+	if !d.isDocument(u.Raw) || !e.Session.Config().IsDomainInScope(u.Host) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	resp, err := support.FetchURL(ctx, u.Raw)
+	if err != nil || resp.StatusCode != 200 {
+		return nil
+	}
+	support.RecordBandwidth(e.Session, d.name, 0, int64(len(resp.Body)))
+
+	var meta string
+	if strings.HasSuffix(strings.ToLower(u.Raw), ".pdf") {
+		meta = d.pdfMetadata(resp.Body)
+	} else {
+		meta = d.ooxmlMetadata([]byte(resp.Body))
+	}
+	if meta != "" {
+		d.process(e, u, meta)
+	}
+	return nil
+}
+
+func (d *docMeta) isDocument(raw string) bool {
+	lower := strings.ToLower(raw)
+	for _, ext := range docExtensions {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// pdfMetadata pulls the info dictionary strings out of a PDF body.
+func (d *docMeta) pdfMetadata(body string) string {
+	var values []string
+	for _, m := range pdfInfoRE.FindAllStringSubmatch(body, -1) {
+		values = append(values, m[2])
+	}
+	return strings.Join(values, "\n")
+}
+
+// ooxmlMetadata reads the core properties from an Office Open XML archive.
+func (d *docMeta) ooxmlMetadata(body []byte) string {
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return ""
+	}
+
+	var values []string
+	for _, f := range zr.File {
+		if !strings.HasPrefix(f.Name, "docProps/") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			continue
+		}
+
+		buf := new(bytes.Buffer)
+		_, _ = buf.ReadFrom(rc)
+		_ = rc.Close()
+
+		for _, m := range xmlTagRE.FindAllStringSubmatch(buf.String(), -1) {
+			values = append(values, m[1])
+		}
+	}
+	return strings.Join(values, "\n")
+}
+
+// process records what the metadata revealed, related to the document URL.
+func (d *docMeta) process(e *types.Event, u *oamurl.URL, meta string) {
+	cfg := e.Session.Config()
+
+	for _, name := range support.ExtractNames(cfg, meta) {
+		support.SubmitFQDNGuess(e, name)
+	}
+	for _, email := range support.ExtractEmails(cfg, meta) {
+		support.Graph(e.Session).Enqueue(e.Asset, "found_in_document", &contact.EmailAddress{Address: email})
+	}
+	for _, path := range pathRE.FindAllString(meta, -1) {
+		support.AddProperty(e.Session, u.Raw, "internal_path", path)
+	}
+
+	for _, line := range strings.Split(meta, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.ContainsAny(line, "@/\\.") {
+			support.AddProperty(e.Session, u.Raw, "document_author", line)
+		}
+	}
+}
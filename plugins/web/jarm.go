@@ -0,0 +1,219 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// tlsFingerprintOption enables active TLS stack fingerprinting.
+const tlsFingerprintOption = "tls/fingerprint"
+
+// jarm collects a JARM-style active TLS fingerprint for discovered TLS
+// services: the server's responses to a series of varied client hellos are
+// hashed into a stable identifier of its TLS stack. Shared fingerprints
+// link infrastructure operated by the same stack across IP ranges.
+type jarm struct {
+	name string
+}
+
+// TLSCluster groups the session's hosts answering with the same TLS stack
+// fingerprint.
+type TLSCluster struct {
+	Fingerprint string
+	Hosts       []string
+}
+
+var stackLock sync.Mutex
+var stacks = make(map[uuid.UUID]map[string]*TLSCluster)
+
+func init() {
+	support.OnSessionCleanup(func(id uuid.UUID) {
+		stackLock.Lock()
+		delete(stacks, id)
+		stackLock.Unlock()
+	})
+}
+
+// probeConfigs are the handshake variations sent to each server. The
+// spread of versions and cipher preferences is what differentiates stacks.
+var probeConfigs = []*tls.Config{
+	{MaxVersion: tls.VersionTLS12, InsecureSkipVerify: true},
+	{MinVersion: tls.VersionTLS13, MaxVersion: tls.VersionTLS13, InsecureSkipVerify: true},
+	{MaxVersion: tls.VersionTLS12, InsecureSkipVerify: true,
+		CipherSuites: []uint16{tls.TLS_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256}},
+}
+
+// NewJARM returns the TLS stack fingerprinting plugin.
+func NewJARM() types.Plugin {
+	return &jarm{name: "TLS-JARM"}
+}
+
+func (j *jarm) Name() string {
+	return j.name
+}
+
+func (j *jarm) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     j,
+		Name:       j.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   j.check,
+	})
+}
+
+func (j *jarm) Stop() {}
+
+func (j *jarm) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	cfg := e.Session.Config()
+	if !support.OptionEnabled(cfg, tlsFingerprintOption) || !cfg.IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+	if !support.HoldForEngagementWindow(e) {
+		return nil
+	}
+
+	fingerprint, sent, received := fingerprintHost(fqdn.Name)
+	support.RecordBandwidth(e.Session, j.name, sent, received)
+	if fingerprint == "" {
+		return nil
+	}
+	support.AddProperty(e.Session, fqdn.Name, "tls:fingerprint", fingerprint)
+
+	j.cluster(e, fqdn.Name, fingerprint)
+	return nil
+}
+
+// cluster files the host under its fingerprint and raises an informational
+// finding once the stack is shared.
+func (j *jarm) cluster(e *types.Event, host, fingerprint string) {
+	stackLock.Lock()
+	id := e.Session.ID()
+	if _, found := stacks[id]; !found {
+		stacks[id] = make(map[string]*TLSCluster)
+	}
+	c, found := stacks[id][fingerprint]
+	if !found {
+		c = &TLSCluster{Fingerprint: fingerprint}
+		stacks[id][fingerprint] = c
+	}
+	prior := append([]string(nil), c.Hosts...)
+	c.Hosts = append(c.Hosts, host)
+	stackLock.Unlock()
+
+	if len(prior) > 0 {
+		support.AddFinding(e.Session, &support.Finding{
+			Type:        "tls:cluster",
+			Asset:       host,
+			Description: "the TLS stack fingerprint matches " + strings.Join(prior, ", "),
+			Severity:    "info",
+			Source:      j.name,
+		})
+	}
+}
+
+// TLSClusters returns the TLS stack clusters built for the session.
+func TLSClusters(id uuid.UUID) []*TLSCluster {
+	stackLock.Lock()
+	defer stackLock.Unlock()
+
+	var results []*TLSCluster
+	for _, c := range stacks[id] {
+		results = append(results, c)
+	}
+	return results
+}
+
+// fingerprintHost probes the host with each handshake variation and hashes
+// the observed responses into the fingerprint, returning the bytes the
+// probes transferred. Failed probes contribute a fixed marker, since
+// refusal patterns also distinguish stacks.
+func fingerprintHost(host string) (string, int64, int64) {
+	var observations []string
+	var answered bool
+	var sent, received int64
+
+	for _, probe := range probeConfigs {
+		probe := probe.Clone()
+		probe.ServerName = host
+
+		obs, out, in := probeOnce(host, probe)
+		if obs != "none" {
+			answered = true
+		}
+		observations = append(observations, obs)
+		sent += out
+		received += in
+	}
+	if !answered {
+		return "", sent, received
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(observations, "|")))
+	return hex.EncodeToString(sum[:31]), sent, received
+}
+
+// countingConn tallies the bytes a probe connection transfers.
+type countingConn struct {
+	net.Conn
+	read, written int64
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.read += int64(n)
+	return n, err
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.written += int64(n)
+	return n, err
+}
+
+// probeOnce performs one handshake and summarizes the server's selections,
+// returning the bytes sent and received. The dial is derived from the
+// engine context, so the kill switch also interrupts handshakes already
+// underway.
+func probeOnce(host string, probe *tls.Config) (string, int64, int64) {
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+
+	raw, err := dialer.DialContext(support.EngineContext(), "tcp", host+":443")
+	if err != nil {
+		return "none", 0, 0
+	}
+	counted := &countingConn{Conn: raw}
+	conn := tls.Client(counted, probe)
+	defer conn.Close()
+
+	_ = counted.SetDeadline(time.Now().Add(5 * time.Second))
+	if err := conn.Handshake(); err != nil {
+		return "none", counted.written, counted.read
+	}
+
+	state := conn.ConnectionState()
+	return fmt.Sprintf("%x:%x:%s", state.Version, state.CipherSuite, state.NegotiatedProtocol),
+		counted.written, counted.read
+}
@@ -0,0 +1,139 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamurl "github.com/owasp-amass/open-asset-model/url"
+)
+
+// bucketMarkers identify cloud storage endpoints inside JavaScript strings.
+var bucketMarkers = []string{
+	".s3.amazonaws.com",
+	".blob.core.windows.net",
+	".storage.googleapis.com",
+	".digitaloceanspaces.com",
+}
+
+// jsAnalysis downloads JavaScript assets from discovered web services and
+// statically extracts hostnames, API endpoints, and cloud bucket URLs by
+// tokenizing string literals rather than applying regexes to raw source.
+type jsAnalysis struct {
+	name string
+}
+
+// NewJSAnalysis returns the JavaScript static analysis plugin.
+func NewJSAnalysis() types.Plugin {
+	return &jsAnalysis{name: "Web-JS-Analysis"}
+}
+
+func (j *jsAnalysis) Name() string {
+	return j.name
+}
+
+func (j *jsAnalysis) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     j,
+		Name:       j.name + "-Handler",
+		Transforms: []string{"fqdn", "url"},
+		EventType:  oam.URL,
+		Callback:   j.check,
+	})
+}
+
+func (j *jsAnalysis) Stop() {}
+
+func (j *jsAnalysis) check(e *types.Event) error {
+	u, ok := e.Asset.Asset.(*oamurl.URL)
+	if !ok {
+		return errors.New("failed to extract the URL asset")
+	}
+	if !strings.HasSuffix(strings.ToLower(u.Path), ".js") {
+		return nil
+	}
+	if !e.Session.Config().IsDomainInScope(u.Host) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	resp, err := support.FetchURL(ctx, u.Raw)
+	if err != nil || resp.StatusCode != 200 {
+		return nil
+	}
+	support.RecordBandwidth(e.Session, j.name, 0, int64(len(resp.Body)))
+
+	for _, literal := range stringLiterals(resp.Body) {
+		j.process(e, u, literal)
+	}
+	return nil
+}
+
+// process classifies a single string literal from the source.
+func (j *jsAnalysis) process(e *types.Event, u *oamurl.URL, literal string) {
+	cfg := e.Session.Config()
+
+	for _, marker := range bucketMarkers {
+		if strings.Contains(literal, marker) {
+			support.AddProperty(e.Session, u.Raw, "cloud_bucket", literal)
+			return
+		}
+	}
+
+	if strings.HasPrefix(literal, "/") && len(literal) > 1 && !strings.Contains(literal, " ") {
+		support.AddProperty(e.Session, u.Raw, "endpoint", literal)
+		return
+	}
+
+	for _, name := range support.ExtractNames(cfg, literal) {
+		support.SubmitFQDNGuess(e, name)
+	}
+}
+
+// stringLiterals tokenizes the JavaScript source and returns the contents
+// of its string and template literals.
+func stringLiterals(src string) []string {
+	var results []string
+	var quote byte
+	var sb strings.Builder
+
+	inString := false
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+
+		if !inString {
+			if c == '\'' || c == '"' || c == '`' {
+				inString = true
+				quote = c
+				sb.Reset()
+			}
+			continue
+		}
+
+		switch c {
+		case '\\':
+			if i+1 < len(src) {
+				sb.WriteByte(src[i+1])
+				i++
+			}
+		case quote:
+			inString = false
+			if s := sb.String(); len(s) > 2 && len(s) < 2048 {
+				results = append(results, s)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return results
+}
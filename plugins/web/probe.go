@@ -0,0 +1,126 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// securityHeaders are the response headers captured as asset properties.
+var securityHeaders = []string{
+	"Strict-Transport-Security",
+	"Content-Security-Policy",
+	"X-Frame-Options",
+	"X-Content-Type-Options",
+	"Referrer-Policy",
+	"Permissions-Policy",
+	"Server",
+}
+
+// webProbe probes in-scope names for HTTP services, recording redirect
+// chains (including cross-domain hops that may expand scope candidates) and
+// response security headers as asset properties.
+type webProbe struct {
+	name string
+}
+
+// NewProbe returns the plugin probing discovered names for web services.
+func NewProbe() types.Plugin {
+	return &webProbe{name: "Web-Probe"}
+}
+
+func (w *webProbe) Name() string {
+	return w.name
+}
+
+func (w *webProbe) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     w,
+		Name:       w.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   w.check,
+	})
+}
+
+func (w *webProbe) Stop() {}
+
+func (w *webProbe) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+	if !e.Session.Config().IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+	if support.BandwidthExceeded(e.Session) {
+		return nil
+	}
+	if !support.HoldForEngagementWindow(e) {
+		return nil
+	}
+
+	for _, scheme := range []string{"https", "http"} {
+		if w.probe(e, fqdn.Name, scheme) {
+			break
+		}
+	}
+	return nil
+}
+
+// probe requests the service and records headers and the redirect chain.
+func (w *webProbe) probe(e *types.Event, name, scheme string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := support.FetchURL(ctx, scheme+"://"+name+"/")
+	if err != nil {
+		return false
+	}
+
+	support.RecordBandwidth(e.Session, w.name, 0, int64(len(resp.Body)))
+	recordContent(e.Session.ID(), name, resp.Body)
+
+	if support.CaptureEnabled(e, w.name) {
+		support.CaptureDebug(e, w.name, scheme+"-response", []byte(resp.Body))
+	}
+
+	for _, header := range securityHeaders {
+		if v := resp.Header.Get(header); v != "" {
+			support.AddProperty(e.Session, name, "header:"+strings.ToLower(header), v)
+		}
+	}
+
+	for i, hop := range resp.Chain {
+		support.AddProperty(e.Session, name, "redirect", hop)
+		if i == len(resp.Chain)-1 {
+			w.crossDomain(e, name, hop)
+		}
+	}
+	return true
+}
+
+// crossDomain submits the redirect target when it lands on another name,
+// surfacing potential scope expansion candidates.
+func (w *webProbe) crossDomain(e *types.Event, name, location string) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host != "" && host != name {
+		support.SubmitFQDNGuess(e, host)
+	}
+}
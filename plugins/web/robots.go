@@ -0,0 +1,143 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	oamurl "github.com/owasp-amass/open-asset-model/url"
+)
+
+var locRE = regexp.MustCompile(`<loc>\s*([^<\s]+)\s*</loc>`)
+
+// robots fetches robots.txt and sitemaps from discovered HTTP services and
+// extracts the hostnames and URLs they reference — a cheap, high-signal
+// source of content that crawling often misses.
+type robots struct {
+	name string
+}
+
+// NewRobots returns the robots.txt and sitemap harvesting plugin.
+func NewRobots() types.Plugin {
+	return &robots{name: "Web-Robots"}
+}
+
+func (r *robots) Name() string {
+	return r.name
+}
+
+func (r *robots) Start(reg types.Registry) error {
+	return reg.RegisterHandler(&types.Handler{
+		Plugin:     r,
+		Name:       r.name + "-Handler",
+		Transforms: []string{"fqdn", "url"},
+		EventType:  oam.FQDN,
+		Callback:   r.check,
+	})
+}
+
+func (r *robots) Stop() {}
+
+func (r *robots) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+	if !e.Session.Config().IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	sitemaps := []string{"https://" + fqdn.Name + "/sitemap.xml"}
+	if resp, err := support.FetchURL(ctx, "https://"+fqdn.Name+"/robots.txt"); err == nil && resp.StatusCode == 200 {
+		support.RecordBandwidth(e.Session, r.name, 0, int64(len(resp.Body)))
+		sitemaps = append(sitemaps, r.processRobots(e, fqdn.Name, resp.Body)...)
+	}
+
+	seen := make(map[string]struct{})
+	for _, sm := range sitemaps {
+		if _, found := seen[sm]; found {
+			continue
+		}
+		seen[sm] = struct{}{}
+		r.processSitemap(ctx, e, sm)
+	}
+	return nil
+}
+
+// processRobots extracts referenced paths and sitemap locations.
+func (r *robots) processRobots(e *types.Event, name, body string) []string {
+	var sitemaps []string
+
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+
+		if lower := strings.ToLower(line); strings.HasPrefix(lower, "sitemap:") {
+			if sm := strings.TrimSpace(line[len("sitemap:"):]); sm != "" {
+				sitemaps = append(sitemaps, sm)
+			}
+		} else if strings.HasPrefix(lower, "allow:") || strings.HasPrefix(lower, "disallow:") {
+			path := strings.TrimSpace(line[strings.Index(line, ":")+1:])
+			if len(path) > 1 && !strings.ContainsAny(path, "*$") {
+				r.submitURL(e, "https://"+name+path)
+			}
+		}
+	}
+	return sitemaps
+}
+
+// processSitemap extracts the URLs listed in a sitemap document.
+func (r *robots) processSitemap(ctx context.Context, e *types.Event, loc string) {
+	resp, err := support.FetchURL(ctx, loc)
+	if err != nil || resp.StatusCode != 200 {
+		return
+	}
+	support.RecordBandwidth(e.Session, r.name, 0, int64(len(resp.Body)))
+
+	for _, m := range locRE.FindAllStringSubmatch(resp.Body, -1) {
+		r.submitURL(e, strings.TrimSpace(m[1]))
+	}
+	for _, name := range support.ExtractNames(e.Session.Config(), resp.Body) {
+		support.SubmitFQDNGuess(e, name)
+	}
+}
+
+// submitURL records the URL on the graph when its host is in scope.
+func (r *robots) submitURL(e *types.Event, raw string) {
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return
+	}
+	if !e.Session.Config().IsDomainInScope(u.Hostname()) {
+		return
+	}
+
+	asset := &oamurl.URL{
+		Raw:    raw,
+		Scheme: u.Scheme,
+		Host:   strings.ToLower(u.Hostname()),
+		Path:   u.Path,
+	}
+	if a, err := support.Graph(e.Session).Create(e.Asset, "references", asset); err == nil && a != nil {
+		_ = e.Dispatcher.DispatchEvent(&types.Event{
+			Name:        raw,
+			Asset:       a,
+			Session:     e.Session,
+			Dispatcher:  e.Dispatcher,
+			Correlation: e.Correlation,
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/chromedp/chromedp"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// screenshotOption enables thumbnail capture of discovered web services.
+const screenshotOption = "web/screenshots"
+
+// screenshots captures thumbnails of discovered HTTP services into the
+// session artifact store and links them to the corresponding assets.
+type screenshots struct {
+	name string
+	sem  chan struct{}
+}
+
+// NewScreenshots returns the optional screenshot capture plugin.
+func NewScreenshots() types.Plugin {
+	return &screenshots{
+		name: "Web-Screenshots",
+		sem:  make(chan struct{}, 4),
+	}
+}
+
+func (s *screenshots) Name() string {
+	return s.name
+}
+
+func (s *screenshots) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:     s,
+		Name:       s.name + "-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   s.check,
+	})
+}
+
+func (s *screenshots) Stop() {}
+
+func (s *screenshots) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+	if !support.OptionEnabled(e.Session.Config(), screenshotOption) {
+		return nil
+	}
+	if !e.Session.Config().IsDomainInScope(fqdn.Name) {
+		return nil
+	}
+	if !support.HoldForEngagementWindow(e) {
+		return nil
+	}
+
+	s.sem <- struct{}{}
+	defer func() { <-s.sem }()
+
+	buf, err := s.capture("https://" + fqdn.Name + "/")
+	if err != nil {
+		return nil
+	}
+
+	path, err := support.SaveArtifact(e.Session, fqdn.Name+".png", buf)
+	if err != nil {
+		return err
+	}
+
+	support.AddProperty(e.Session, fqdn.Name, "screenshot", path)
+	return nil
+}
+
+// capture renders the page in the headless browser pool and returns a
+// thumbnail-quality screenshot.
+func (s *screenshots) capture(url string) ([]byte, error) {
+	ctx, cancel := chromedp.NewContext(context.Background())
+	defer cancel()
+
+	ctx, tcancel := context.WithTimeout(ctx, time.Minute)
+	defer tcancel()
+
+	var buf []byte
+	err := chromedp.Run(ctx,
+		chromedp.EmulateViewport(1280, 800),
+		chromedp.Navigate(url),
+		chromedp.Sleep(2*time.Second),
+		chromedp.CaptureScreenshot(&buf),
+	)
+	return buf, err
+}
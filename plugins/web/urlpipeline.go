@@ -0,0 +1,136 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package web
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	oamurl "github.com/owasp-amass/open-asset-model/url"
+)
+
+// urlPipeline is the first-class handler for URL assets: it normalizes and
+// deduplicates discovered URLs and relates each to the FQDN serving it, so
+// crawl and JS-analysis plugins can register for the URL event type rather
+// than overloading FQDN events.
+type urlPipeline struct {
+	name string
+
+	lock sync.Mutex
+	seen map[uuid.UUID]map[string]struct{}
+}
+
+// NewURLPipeline returns the plugin processing URL assets.
+func NewURLPipeline() types.Plugin {
+	u := &urlPipeline{
+		name: "URL-Pipeline",
+		seen: make(map[uuid.UUID]map[string]struct{}),
+	}
+
+	support.OnSessionCleanup(func(id uuid.UUID) {
+		u.lock.Lock()
+		delete(u.seen, id)
+		u.lock.Unlock()
+	})
+	return u
+}
+
+func (u *urlPipeline) Name() string {
+	return u.name
+}
+
+func (u *urlPipeline) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin: u,
+		Name:   u.name + "-Handler",
+		// The pipeline runs ahead of the other URL handlers so they only
+		// ever see normalized, deduplicated assets.
+		Priority:   1,
+		Transforms: []string{"url", "fqdn"},
+		EventType:  oam.URL,
+		Callback:   u.check,
+	})
+}
+
+func (u *urlPipeline) Stop() {}
+
+func (u *urlPipeline) check(e *types.Event) error {
+	asset, ok := e.Asset.Asset.(*oamurl.URL)
+	if !ok {
+		return errors.New("failed to extract the URL asset")
+	}
+
+	normalized, host, port := normalizeURL(asset.Raw)
+	if normalized == "" || !e.Session.Config().IsDomainInScope(host) {
+		return nil
+	}
+	if u.duplicate(e.Session.ID(), normalized) {
+		return nil
+	}
+
+	asset.Raw = normalized
+	asset.Host = host
+
+	// Relate the URL to the name and port serving it.
+	if a, err := support.Graph(e.Session).Create(e.Asset, "domain", &oamdns.FQDN{Name: host}); err == nil && a != nil {
+		support.SubmitFQDNGuess(e, host)
+	}
+	if port != "" {
+		support.AddProperty(e.Session, normalized, "url:port", port)
+	}
+	support.AddProperty(e.Session, host, "url:known", normalized)
+	return nil
+}
+
+// duplicate reports whether the normalized form was already processed for
+// the session.
+func (u *urlPipeline) duplicate(id uuid.UUID, normalized string) bool {
+	u.lock.Lock()
+	defer u.lock.Unlock()
+
+	if _, found := u.seen[id]; !found {
+		u.seen[id] = make(map[string]struct{})
+	}
+	if _, found := u.seen[id][normalized]; found {
+		return true
+	}
+	u.seen[id][normalized] = struct{}{}
+	return false
+}
+
+// normalizeURL lowercases the scheme and host, strips default ports and
+// fragments, and returns the normalized form with its host and port.
+func normalizeURL(raw string) (string, string, string) {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Hostname() == "" {
+		return "", "", ""
+	}
+
+	scheme := strings.ToLower(parsed.Scheme)
+	host := strings.ToLower(parsed.Hostname())
+	port := parsed.Port()
+	if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		port = ""
+	}
+
+	normalized := scheme + "://" + host
+	if port != "" {
+		normalized += ":" + port
+	}
+	if parsed.Path != "" && parsed.Path != "/" {
+		normalized += parsed.Path
+	}
+	if parsed.RawQuery != "" {
+		normalized += "?" + parsed.RawQuery
+	}
+	return normalized, host, port
+}
@@ -0,0 +1,115 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/owasp-amass/config/config"
+	"github.com/owasp-amass/engine/plugins/support"
+	oam "github.com/owasp-amass/open-asset-model"
+)
+
+// defaultPipelineWorkers is the per-asset-type concurrency used when no
+// explicit value has been configured.
+const defaultPipelineWorkers = 25
+
+// pipeline tracks the concurrency budget and utilization for one asset
+// type. FQDN, IP, and email workloads differ enough that a single hidden
+// default serves none of them well.
+type pipeline struct {
+	slots     chan struct{}
+	active    atomic.Int64
+	processed atomic.Int64
+}
+
+// PipelineStats reports the utilization of one asset-type pipeline.
+type PipelineStats struct {
+	Workers   int
+	Active    int64
+	Processed int64
+}
+
+func (r *Registry) getPipeline(atype oam.AssetType) *pipeline {
+	r.pipeLock.Lock()
+	defer r.pipeLock.Unlock()
+
+	p, found := r.pipelines[atype]
+	if !found {
+		p = &pipeline{slots: make(chan struct{}, defaultPipelineWorkers)}
+		r.pipelines[atype] = p
+	}
+	return p
+}
+
+// SetPipelineWorkers sets the number of parallel workers permitted for the
+// asset type's pipeline. Work in flight against the old budget drains
+// naturally; new acquisitions use the new one.
+func (r *Registry) SetPipelineWorkers(atype oam.AssetType, workers int) {
+	if workers <= 0 {
+		workers = defaultPipelineWorkers
+	}
+
+	r.pipeLock.Lock()
+	defer r.pipeLock.Unlock()
+
+	p, found := r.pipelines[atype]
+	if !found {
+		r.pipelines[atype] = &pipeline{slots: make(chan struct{}, workers)}
+		return
+	}
+	if cap(p.slots) != workers {
+		p.slots = make(chan struct{}, workers)
+	}
+}
+
+// AcquireSlot blocks until the asset type's pipeline has a free worker and
+// returns the function releasing it.
+func (r *Registry) AcquireSlot(atype oam.AssetType) func() {
+	p := r.getPipeline(atype)
+
+	// The channel is captured so a concurrent budget change cannot leave
+	// the release draining from a different channel than was filled.
+	slots := p.slots
+	slots <- struct{}{}
+	p.active.Add(1)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			<-slots
+			p.active.Add(-1)
+			p.processed.Add(1)
+		})
+	}
+}
+
+// ConfigurePipelines applies the "pipeline/<asset-type>/workers" options
+// from the provided configuration.
+func (r *Registry) ConfigurePipelines(cfg *config.Config) {
+	for _, atype := range []oam.AssetType{oam.FQDN, oam.IPAddress, oam.EmailAddress, oam.URL} {
+		if n := support.OptionInt(cfg, "pipeline/"+string(atype)+"/workers", 0); n > 0 {
+			r.SetPipelineWorkers(atype, n)
+		}
+	}
+}
+
+// PipelineUtilization returns live per-pipeline stats: the configured
+// worker count, currently active workers, and total events processed.
+func (r *Registry) PipelineUtilization() map[oam.AssetType]PipelineStats {
+	r.pipeLock.Lock()
+	defer r.pipeLock.Unlock()
+
+	results := make(map[oam.AssetType]PipelineStats, len(r.pipelines))
+	for atype, p := range r.pipelines {
+		results[atype] = PipelineStats{
+			Workers:   cap(p.slots),
+			Active:    p.active.Load(),
+			Processed: p.processed.Load(),
+		}
+	}
+	return results
+}
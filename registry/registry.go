@@ -0,0 +1,90 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"errors"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+)
+
+// Registry maintains the plugin handlers registered for each asset type.
+type Registry struct {
+	sync.RWMutex
+	log      *slog.Logger
+	handlers map[oam.AssetType][]*types.Handler
+
+	pipeLock  sync.Mutex
+	pipelines map[oam.AssetType]*pipeline
+}
+
+// NewRegistry returns an empty handler registry.
+func NewRegistry(log *slog.Logger) *Registry {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &Registry{
+		log:       log,
+		handlers:  make(map[oam.AssetType][]*types.Handler),
+		pipelines: make(map[oam.AssetType]*pipeline),
+	}
+}
+
+// Log implements the types.Registry interface.
+func (r *Registry) Log() *slog.Logger {
+	return r.log
+}
+
+// RegisterHandler implements the types.Registry interface. Handlers for the
+// same asset type are kept ordered by priority.
+func (r *Registry) RegisterHandler(h *types.Handler) error {
+	if h == nil || h.Callback == nil {
+		return errors.New("the handler is missing a callback")
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	for _, existing := range r.handlers[h.EventType] {
+		if existing.Name == h.Name {
+			return errors.New("a handler named " + h.Name + " is already registered")
+		}
+	}
+
+	r.handlers[h.EventType] = append(r.handlers[h.EventType], h)
+	sort.SliceStable(r.handlers[h.EventType], func(i, j int) bool {
+		return r.handlers[h.EventType][i].Priority < r.handlers[h.EventType][j].Priority
+	})
+	return nil
+}
+
+// DeregisterHandler implements the types.Registry interface.
+func (r *Registry) DeregisterHandler(eventType oam.AssetType, name string) error {
+	r.Lock()
+	defer r.Unlock()
+
+	for i, h := range r.handlers[eventType] {
+		if h.Name == name {
+			r.handlers[eventType] = append(r.handlers[eventType][:i], r.handlers[eventType][i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("no handler named " + name + " is registered for the asset type")
+}
+
+// GetHandlers returns the handlers registered for the asset type in
+// priority order.
+func (r *Registry) GetHandlers(eventType oam.AssetType) []*types.Handler {
+	r.RLock()
+	defer r.RUnlock()
+
+	results := make([]*types.Handler, len(r.handlers[eventType]))
+	copy(results, r.handlers[eventType])
+	return results
+}
@@ -0,0 +1,80 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+)
+
+// ScheduleContext adds the event to the queue bound to the context: once
+// the context is cancelled the event is retired without running, and any
+// events depending on it are cancelled in turn. Cancelling an individual
+// event is done by scheduling it with its own derived context.
+func (s *Scheduler) ScheduleContext(ctx context.Context, e *Event) error {
+	if ctx == nil {
+		return errors.New("a context is required for context-aware scheduling")
+	}
+	if e == nil {
+		return errors.New("the event is not valid for scheduling")
+	}
+	e.ctx = ctx
+
+	// Cancellation must wake Process loops blocked on the condition
+	// variable so the prune pass can retire the event promptly.
+	stop := context.AfterFunc(ctx, func() {
+		s.queueLock.Lock()
+		s.wake.Broadcast()
+		s.queueLock.Unlock()
+	})
+
+	if err := s.Schedule(e); err != nil {
+		stop()
+		return err
+	}
+	return nil
+}
+
+// ProcessContext executes queued events like Process, returning early once
+// the context is cancelled. Events already executing finish their action;
+// everything still queued remains for a later Process call unless its own
+// context was also cancelled.
+func (s *Scheduler) ProcessContext(ctx context.Context, cfg *ProcessConfig) error {
+	if ctx == nil {
+		return errors.New("a context is required for context-aware processing")
+	}
+	if cfg == nil {
+		cfg = &ProcessConfig{ExitWhenEmpty: true}
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	// Wake the blocked loop the moment the context is cancelled.
+	stop := context.AfterFunc(ctx, func() {
+		s.queueLock.Lock()
+		s.wake.Broadcast()
+		s.queueLock.Unlock()
+	})
+	defer stop()
+
+	var count int
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if cfg.MaxEvents > 0 && count >= cfg.MaxEvents {
+			return nil
+		}
+
+		e := s.next(ctx, cfg.MinPriority, cfg.ExitWhenEmpty)
+		if e == nil {
+			return ctx.Err()
+		}
+
+		count++
+		s.execute(e)
+	}
+}
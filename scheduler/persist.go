@@ -0,0 +1,149 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// journalRecord is one line of the scheduler journal: a scheduling with the
+// event's metadata, or a lifecycle transition by ID.
+type journalRecord struct {
+	Op        string      `json:"op"`
+	ID        uuid.UUID   `json:"id"`
+	Priority  int         `json:"priority,omitempty"`
+	DependsOn []uuid.UUID `json:"depends_on,omitempty"`
+	Payload   string      `json:"payload,omitempty"`
+}
+
+const (
+	opScheduled = "scheduled"
+	opDone      = "done"
+	opError     = "error"
+	opCancelled = "cancelled"
+)
+
+// schedJournal appends lifecycle records to the backing file.
+type schedJournal struct {
+	sync.Mutex
+	path string
+	f    *os.File
+	enc  *json.Encoder
+}
+
+// NewPersistentScheduler returns a scheduler that journals scheduled events
+// and their states to the file, so a Recover call after a crash can reload
+// the work that never finished. Actions are code and cannot be persisted;
+// events meant to survive a restart carry their rebuild data in Payload.
+func NewPersistentScheduler(log *slog.Logger, path string) (*Scheduler, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0640)
+	if err != nil {
+		return nil, err
+	}
+
+	s := NewScheduler(log)
+	s.journal = &schedJournal{path: path, f: f, enc: json.NewEncoder(f)}
+	return s, nil
+}
+
+// record appends one journal line; schedulers without persistence skip it.
+func (s *Scheduler) record(op string, e *Event) {
+	if s.journal == nil || e == nil {
+		return
+	}
+
+	s.journal.Lock()
+	defer s.journal.Unlock()
+
+	r := &journalRecord{Op: op, ID: e.ID}
+	if op == opScheduled {
+		r.Priority = e.Priority
+		r.DependsOn = e.DependsOn
+		r.Payload = e.Payload
+	}
+	if err := s.journal.enc.Encode(r); err != nil {
+		s.log.Warn("failed to journal a scheduler event", "event", e.ID.String(), "err", err)
+	}
+}
+
+// Recover reloads unfinished events from the journal and reschedules them.
+// The rebind function reconstructs each event's action from the payload it
+// was scheduled with; returning nil skips the event. Recover returns how
+// many events were rescheduled.
+func (s *Scheduler) Recover(rebind func(id uuid.UUID, payload string) func() error) (int, error) {
+	if s.journal == nil {
+		return 0, errors.New("the scheduler has no persistence layer")
+	}
+	if rebind == nil {
+		return 0, errors.New("a rebind function is required for recovery")
+	}
+
+	f, err := os.Open(s.journal.path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	// The final state of each ID wins; anything not terminal is unfinished.
+	pending := make(map[uuid.UUID]*journalRecord)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r journalRecord
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+
+		switch r.Op {
+		case opScheduled:
+			cp := r
+			pending[r.ID] = &cp
+		case opDone, opError, opCancelled:
+			delete(pending, r.ID)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, r := range pending {
+		action := rebind(r.ID, r.Payload)
+		if action == nil {
+			continue
+		}
+
+		e := &Event{
+			ID:        r.ID,
+			Priority:  r.Priority,
+			DependsOn: r.DependsOn,
+			Payload:   r.Payload,
+			Action:    action,
+		}
+		if err := s.Schedule(e); err != nil {
+			s.log.Warn("failed to reschedule a recovered event", "event", r.ID.String(), "err", err)
+			continue
+		}
+		count++
+	}
+	return count, nil
+}
+
+// Close releases the journal file when persistence is enabled.
+func (s *Scheduler) Close() error {
+	if s.journal == nil {
+		return nil
+	}
+
+	s.journal.Lock()
+	defer s.journal.Unlock()
+	return s.journal.f.Close()
+}
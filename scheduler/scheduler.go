@@ -0,0 +1,453 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package scheduler orders and executes engine work items with priorities
+// and dependencies. Locking is deliberately fine-grained: the queue lock is
+// held only while popping or appending, each event guards its own state,
+// and the counters are atomics, so inspection, rescheduling, and stat
+// updates never serialize the processing loop.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+)
+
+// EventState tracks an event through its lifecycle on the scheduler.
+type EventState int
+
+const (
+	StateWaiting EventState = iota
+	StateProcessing
+	StateDone
+	StateError
+	StateCancelled
+)
+
+// Event is one schedulable unit of work.
+type Event struct {
+	ID       uuid.UUID
+	Priority int
+	// DependsOn lists events that must reach StateDone before this one
+	// becomes eligible to run.
+	DependsOn []uuid.UUID
+	Action    func() error
+	// ActionCtx, when set, runs instead of Action and receives the context
+	// the event was scheduled with, so it can abort promptly on cancellation.
+	ActionCtx func(ctx context.Context) error
+
+	// Payload is opaque client data journaled with the event when the
+	// scheduler is persistent, used to rebuild the action during recovery.
+	Payload string
+
+	// ctx is the cancellation context attached by ScheduleContext; nil for
+	// events scheduled through Schedule.
+	ctx context.Context
+
+	// Each event guards its own state so the processing loop never needs
+	// the scheduler-wide lock to inspect or update it.
+	lock  sync.Mutex
+	state EventState
+}
+
+// State returns the event's current lifecycle state.
+func (e *Event) State() EventState {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return e.state
+}
+
+func (e *Event) setState(s EventState) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.state = s
+}
+
+// run invokes the event's action, preferring the context-aware form and
+// refusing to start at all once the context is cancelled.
+func (e *Event) run() error {
+	ctx := e.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if e.ActionCtx != nil {
+		return e.ActionCtx(ctx)
+	}
+	return e.Action()
+}
+
+// Stats are the scheduler throughput counters, maintained with atomics.
+type Stats struct {
+	Scheduled int64
+	Processed int64
+	Errors    int64
+	Cancelled int64
+}
+
+// Scheduler maintains the priority queue of events awaiting execution.
+type Scheduler struct {
+	log *slog.Logger
+
+	// queueLock only covers the queue slice and the event index; it is
+	// released before any event executes.
+	queueLock sync.Mutex
+	queue     []*Event
+	events    map[uuid.UUID]*Event
+	// cancelled remembers retired cancelled events so their dependents can
+	// be cancelled in turn instead of waiting forever.
+	cancelled map[uuid.UUID]struct{}
+	// failed remembers retired errored events for the same reason: their
+	// dependents must never become runnable, so they are cancelled too.
+	failed map[uuid.UUID]struct{}
+	// wake signals Process loops blocked on an empty queue or unmet
+	// dependencies whenever the queue changes, replacing timed polling.
+	wake *sync.Cond
+
+	scheduled atomic.Int64
+	processed atomic.Int64
+	errors    atomic.Int64
+	cancels   atomic.Int64
+
+	// journal persists event lifecycles when the scheduler was built by
+	// NewPersistentScheduler; nil otherwise.
+	journal *schedJournal
+}
+
+// NewScheduler returns an empty scheduler.
+func NewScheduler(log *slog.Logger) *Scheduler {
+	if log == nil {
+		log = slog.Default()
+	}
+	s := &Scheduler{
+		log:       log,
+		events:    make(map[uuid.UUID]*Event),
+		cancelled: make(map[uuid.UUID]struct{}),
+		failed:    make(map[uuid.UUID]struct{}),
+	}
+	s.wake = sync.NewCond(&s.queueLock)
+	return s
+}
+
+// Schedule adds the event to the queue.
+func (s *Scheduler) Schedule(e *Event) error {
+	if e == nil || (e.Action == nil && e.ActionCtx == nil) {
+		return errors.New("the event is not valid for scheduling")
+	}
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	e.setState(StateWaiting)
+
+	s.queueLock.Lock()
+	defer s.queueLock.Unlock()
+
+	if _, found := s.events[e.ID]; found {
+		return errors.New("an event with this ID is already scheduled")
+	}
+	if cycle := s.findCycle(e); cycle != nil {
+		return fmt.Errorf("scheduling would create a dependency cycle: %s", formatCycle(cycle))
+	}
+	s.events[e.ID] = e
+	s.insert(e)
+	s.scheduled.Add(1)
+	s.record(opScheduled, e)
+	s.wake.Broadcast()
+	return nil
+}
+
+// findCycle walks the DependsOn edges reachable from the event across the
+// events map and returns the IDs forming a cycle back to it, or nil when
+// the dependency graph stays acyclic. Cyclic chains would otherwise never
+// become processable and sit in the queue forever. The caller must hold
+// the queue lock.
+func (s *Scheduler) findCycle(e *Event) []uuid.UUID {
+	visited := make(map[uuid.UUID]struct{})
+
+	var walk func(id uuid.UUID, path []uuid.UUID) []uuid.UUID
+	walk = func(id uuid.UUID, path []uuid.UUID) []uuid.UUID {
+		if id == e.ID {
+			return append(path, id)
+		}
+		if _, seen := visited[id]; seen {
+			return nil
+		}
+		visited[id] = struct{}{}
+
+		dep, found := s.events[id]
+		if !found {
+			return nil
+		}
+		for _, next := range dep.DependsOn {
+			if cycle := walk(next, append(path, id)); cycle != nil {
+				return cycle
+			}
+		}
+		return nil
+	}
+
+	for _, id := range e.DependsOn {
+		if cycle := walk(id, []uuid.UUID{e.ID}); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// formatCycle renders the cycle as an arrow-separated ID chain.
+func formatCycle(cycle []uuid.UUID) string {
+	parts := make([]string, len(cycle))
+	for i, id := range cycle {
+		parts[i] = id.String()
+	}
+	return strings.Join(parts, " -> ")
+}
+
+// insert places the event into the queue ordered by descending priority.
+// The caller must hold the queue lock.
+func (s *Scheduler) insert(e *Event) {
+	i := len(s.queue)
+	for ; i > 0 && s.queue[i-1].Priority < e.Priority; i-- {
+	}
+	s.queue = append(s.queue, nil)
+	copy(s.queue[i+1:], s.queue[i:])
+	s.queue[i] = e
+}
+
+// next pops the highest-priority runnable event at or above minPriority,
+// blocking on the condition variable until one arrives. It returns nil when
+// exitWhenEmpty is set and the queue has drained, or once the context is
+// cancelled.
+func (s *Scheduler) next(ctx context.Context, minPriority int, exitWhenEmpty bool) *Event {
+	s.queueLock.Lock()
+	defer s.queueLock.Unlock()
+
+	for {
+		if ctx != nil && ctx.Err() != nil {
+			return nil
+		}
+		s.pruneCancelled()
+
+		for i, e := range s.queue {
+			if e.Priority < minPriority || !s.runnable(e) {
+				continue
+			}
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return e
+		}
+		if exitWhenEmpty && len(s.queue) == 0 {
+			return nil
+		}
+		s.wake.Wait()
+	}
+}
+
+// pruneCancelled retires queued events whose context was cancelled, or
+// whose dependencies were, cascading until the queue is stable. The caller
+// must hold the queue lock.
+func (s *Scheduler) pruneCancelled() {
+	for changed := true; changed; {
+		changed = false
+
+		kept := s.queue[:0]
+		for _, e := range s.queue {
+			if s.cancelEligible(e) {
+				e.setState(StateCancelled)
+				delete(s.events, e.ID)
+				s.cancelled[e.ID] = struct{}{}
+				s.cancels.Add(1)
+				s.record(opCancelled, e)
+				changed = true
+				continue
+			}
+			kept = append(kept, e)
+		}
+		s.queue = kept
+	}
+}
+
+// cancelEligible reports whether the queued event should be cancelled. The
+// caller must hold the queue lock.
+func (s *Scheduler) cancelEligible(e *Event) bool {
+	if e.ctx != nil && e.ctx.Err() != nil {
+		return true
+	}
+	for _, id := range e.DependsOn {
+		if _, found := s.cancelled[id]; found {
+			return true
+		}
+		if _, found := s.failed[id]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// runnable reports whether all of the event's dependencies have completed.
+// The caller must hold the queue lock for the index access.
+func (s *Scheduler) runnable(e *Event) bool {
+	for _, id := range e.DependsOn {
+		if dep, found := s.events[id]; found && dep.State() != StateDone {
+			return false
+		}
+	}
+	return true
+}
+
+// ProcessConfig controls one call to the Process loop.
+type ProcessConfig struct {
+	// MinPriority filters out events below this priority.
+	MinPriority int
+	// MaxEvents stops the loop after this many executions; zero means
+	// no limit.
+	MaxEvents int
+	// Workers sets how many events may execute concurrently; values below
+	// one run the single-threaded loop.
+	Workers int
+	// ExitWhenEmpty returns from Process once the queue drains instead of
+	// waiting for more work.
+	ExitWhenEmpty bool
+}
+
+// Validate reports the first misconfiguration in the ProcessConfig with an
+// actionable message, so bad settings surface before processing starts
+// rather than as silent misbehavior.
+func (cfg *ProcessConfig) Validate() error {
+	if cfg == nil {
+		return nil
+	}
+	if cfg.MinPriority < 0 {
+		return errors.New("MinPriority cannot be negative; use zero to accept every priority")
+	}
+	if cfg.MaxEvents < 0 {
+		return errors.New("MaxEvents cannot be negative; use zero for no limit")
+	}
+	if cfg.Workers < 0 {
+		return errors.New("Workers cannot be negative; use zero or one for single-threaded processing")
+	}
+	if cfg.MaxEvents > 0 && cfg.Workers > cfg.MaxEvents {
+		return errors.New("Workers exceeds MaxEvents; the extra workers would never receive an event")
+	}
+	return nil
+}
+
+// Process executes queued events until the configured exit condition is
+// reached. The loop sleeps on the scheduler's condition variable rather
+// than polling, so wakeups happen the moment work becomes available, and
+// dequeued events are never rescheduled: an executing event stays in the
+// index as StateProcessing, which is what holds its dependents back.
+// Event actions run without any scheduler lock held.
+func (s *Scheduler) Process(cfg *ProcessConfig) {
+	if cfg == nil {
+		cfg = &ProcessConfig{ExitWhenEmpty: true}
+	}
+	if err := cfg.Validate(); err != nil {
+		s.log.Warn("refusing to process with an invalid configuration", "err", err)
+		return
+	}
+	if cfg.Workers > 1 {
+		s.processPool(cfg)
+		return
+	}
+
+	var count int
+	for {
+		if cfg.MaxEvents > 0 && count >= cfg.MaxEvents {
+			return
+		}
+
+		e := s.next(nil, cfg.MinPriority, cfg.ExitWhenEmpty)
+		if e == nil {
+			return
+		}
+
+		count++
+		s.execute(e)
+	}
+}
+
+// processPool feeds dequeued events to a pool of workers over a channel,
+// so executions overlap while the feeding loop still blocks on the
+// condition variable whenever the queue is empty.
+func (s *Scheduler) processPool(cfg *ProcessConfig) {
+	work := make(chan *Event)
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range work {
+				s.execute(e)
+			}
+		}()
+	}
+
+	var count int
+	for {
+		if cfg.MaxEvents > 0 && count >= cfg.MaxEvents {
+			break
+		}
+
+		e := s.next(nil, cfg.MinPriority, cfg.ExitWhenEmpty)
+		if e == nil {
+			break
+		}
+
+		count++
+		work <- e
+	}
+
+	close(work)
+	wg.Wait()
+}
+
+// execute runs a single event and retires it from the index. Errored
+// events are remembered as failed so their dependents cascade into
+// cancellation rather than becoming runnable.
+func (s *Scheduler) execute(e *Event) {
+	e.setState(StateProcessing)
+
+	failed := false
+	if err := e.run(); err != nil {
+		failed = true
+		e.setState(StateError)
+		s.errors.Add(1)
+		s.record(opError, e)
+		s.log.Debug("a scheduled event returned an error", "event", e.ID.String(), "err", err)
+	} else {
+		e.setState(StateDone)
+		s.record(opDone, e)
+	}
+	s.processed.Add(1)
+
+	// Completion can unblock dependents, so waiting loops are woken.
+	s.queueLock.Lock()
+	delete(s.events, e.ID)
+	if failed {
+		s.failed[e.ID] = struct{}{}
+	}
+	s.wake.Broadcast()
+	s.queueLock.Unlock()
+}
+
+// Stats returns a snapshot of the throughput counters.
+func (s *Scheduler) Stats() Stats {
+	return Stats{
+		Scheduled: s.scheduled.Load(),
+		Processed: s.processed.Load(),
+		Errors:    s.errors.Load(),
+		Cancelled: s.cancels.Load(),
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package scheduler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestProcessPriorityOrder(t *testing.T) {
+	s := NewScheduler(nil)
+
+	var order []int
+	for _, priority := range []int{1, 3, 2} {
+		p := priority
+		err := s.Schedule(&Event{
+			Priority: p,
+			Action: func() error {
+				order = append(order, p)
+				return nil
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to schedule the event: %v", err)
+		}
+	}
+
+	s.Process(&ProcessConfig{ExitWhenEmpty: true})
+
+	if len(order) != 3 || order[0] != 3 || order[1] != 2 || order[2] != 1 {
+		t.Errorf("events executed out of priority order: %v", order)
+	}
+}
+
+func TestProcessDependencyOrder(t *testing.T) {
+	s := NewScheduler(nil)
+
+	var order []string
+	first := &Event{
+		ID:     uuid.New(),
+		Action: func() error { order = append(order, "first"); return nil },
+	}
+	second := &Event{
+		ID:        uuid.New(),
+		Priority:  10,
+		DependsOn: []uuid.UUID{first.ID},
+		Action:    func() error { order = append(order, "second"); return nil },
+	}
+
+	// The dependent is scheduled first and with the higher priority, so only
+	// the dependency can be holding it back.
+	if err := s.Schedule(second); err != nil {
+		t.Fatalf("failed to schedule the dependent event: %v", err)
+	}
+	if err := s.Schedule(first); err != nil {
+		t.Fatalf("failed to schedule the dependency: %v", err)
+	}
+
+	s.Process(&ProcessConfig{ExitWhenEmpty: true})
+
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Errorf("events executed out of dependency order: %v", order)
+	}
+}
+
+func TestFailedDependencyCancelsDependents(t *testing.T) {
+	s := NewScheduler(nil)
+
+	failing := &Event{
+		ID:     uuid.New(),
+		Action: func() error { return errors.New("the action failed") },
+	}
+	dependent := &Event{
+		ID:        uuid.New(),
+		DependsOn: []uuid.UUID{failing.ID},
+		Action:    func() error { t.Error("a dependent of an errored event executed"); return nil },
+	}
+	transitive := &Event{
+		ID:        uuid.New(),
+		DependsOn: []uuid.UUID{dependent.ID},
+		Action:    func() error { t.Error("a transitive dependent of an errored event executed"); return nil },
+	}
+
+	for _, e := range []*Event{failing, dependent, transitive} {
+		if err := s.Schedule(e); err != nil {
+			t.Fatalf("failed to schedule the event: %v", err)
+		}
+	}
+
+	s.Process(&ProcessConfig{ExitWhenEmpty: true})
+
+	if state := dependent.State(); state != StateCancelled {
+		t.Errorf("expected the dependent to be cancelled, got state %d", state)
+	}
+	if state := transitive.State(); state != StateCancelled {
+		t.Errorf("expected the transitive dependent to be cancelled, got state %d", state)
+	}
+	if stats := s.Stats(); stats.Errors != 1 || stats.Cancelled != 2 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestScheduleRejectsDependencyCycles(t *testing.T) {
+	s := NewScheduler(nil)
+
+	a := uuid.New()
+	b := uuid.New()
+	if err := s.Schedule(&Event{
+		ID:        a,
+		DependsOn: []uuid.UUID{b},
+		Action:    func() error { return nil },
+	}); err != nil {
+		t.Fatalf("failed to schedule the first event: %v", err)
+	}
+
+	err := s.Schedule(&Event{
+		ID:        b,
+		DependsOn: []uuid.UUID{a},
+		Action:    func() error { return nil },
+	})
+	if err == nil {
+		t.Error("expected scheduling the cycle to fail")
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	assetdb "github.com/owasp-amass/asset-db"
+	pgmigrations "github.com/owasp-amass/asset-db/migrations/postgres"
+	sqlitemigrations "github.com/owasp-amass/asset-db/migrations/sqlite3"
+	"github.com/owasp-amass/asset-db/repository"
+	"github.com/owasp-amass/config/config"
+	migrate "github.com/rubenv/sql-migrate"
+
+	// The drivers used to run the schema migrations before asset-db opens
+	// the stores through gorm.
+	_ "github.com/glebarez/go-sqlite"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// newStores opens the primary database and the working cache for a session.
+// The primary store comes from the configuration: a Postgres database when
+// one is configured as primary, and a SQLite file in the session directory
+// otherwise. The cache is always a SQLite file in the session directory.
+func newStores(cfg *config.Config, dir string) (*assetdb.AssetDB, *assetdb.AssetDB, error) {
+	db, err := newPrimaryStore(cfg, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dsn := filepath.Join(dir, "cache.sqlite")
+	if err := migrateStore(repository.SQLite, dsn); err != nil {
+		return nil, nil, err
+	}
+	cache := assetdb.New(repository.SQLite, dsn)
+	if cache == nil {
+		return nil, nil, errors.New("failed to open the session cache database")
+	}
+	return db, cache, nil
+}
+
+// newPrimaryStore opens the store configured as primary for the session.
+func newPrimaryStore(cfg *config.Config, dir string) (*assetdb.AssetDB, error) {
+	for _, gdb := range cfg.GraphDBs {
+		if gdb == nil || !gdb.Primary {
+			continue
+		}
+
+		if gdb.System == "postgres" {
+			dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s",
+				gdb.Host, gdb.Port, gdb.Username, gdb.Password, gdb.DBName)
+			if err := migrateStore(repository.Postgres, dsn); err != nil {
+				return nil, err
+			}
+			if db := assetdb.New(repository.Postgres, dsn); db != nil {
+				return db, nil
+			}
+			return nil, fmt.Errorf("failed to connect to the %s database at %s", gdb.System, gdb.Host)
+		}
+		break
+	}
+
+	dsn := filepath.Join(dir, "assets.sqlite")
+	if err := migrateStore(repository.SQLite, dsn); err != nil {
+		return nil, err
+	}
+	if db := assetdb.New(repository.SQLite, dsn); db != nil {
+		return db, nil
+	}
+	return nil, errors.New("failed to open the session asset database")
+}
+
+// migrateStore brings the store's schema up to date before asset-db opens
+// it; asset-db ships the migrations but leaves running them to the caller.
+func migrateStore(dbtype repository.DBType, dsn string) error {
+	source := migrate.EmbedFileSystemMigrationSource{Root: "/"}
+
+	var driver, dialect string
+	switch dbtype {
+	case repository.Postgres:
+		driver, dialect = "pgx", "postgres"
+		source.FileSystem = pgmigrations.Migrations()
+	default:
+		driver, dialect = "sqlite", "sqlite3"
+		source.FileSystem = sqlitemigrations.Migrations()
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	_, err = migrate.Exec(db, dialect, source, migrate.Up)
+	return err
+}
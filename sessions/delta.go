@@ -0,0 +1,146 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+// snapshot is what a completed run leaves behind for the next one to diff
+// against, keyed by the session's scope.
+type snapshot struct {
+	Taken    time.Time `json:"taken"`
+	Assets   []string  `json:"assets"`
+	Findings []string  `json:"findings"`
+}
+
+// Delta summarizes what changed between two runs over the same scope.
+type Delta struct {
+	Previous      time.Time `json:"previous"`
+	NewAssets     []string  `json:"new_assets"`
+	RemovedAssets []string  `json:"removed_assets"`
+	NewFindings   []string  `json:"new_findings"`
+}
+
+// ComputeDelta diffs this session against the previous run over the same
+// scope, records the current state for the next run, and raises findings
+// summarizing the changes — turning recurring sessions into continuous
+// infrastructure monitoring. The first run over a scope returns nil.
+func (s *Session) ComputeDelta() (*Delta, error) {
+	path, err := s.snapshotPath()
+	if err != nil {
+		return nil, err
+	}
+
+	current := s.currentSnapshot()
+	defer func() {
+		if data, err := json.Marshal(current); err == nil {
+			_ = os.WriteFile(path, data, 0640)
+		}
+	}()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		// No previous run to diff against; the snapshot becomes the baseline.
+		return nil, nil
+	}
+	var previous snapshot
+	if err := json.Unmarshal(data, &previous); err != nil {
+		return nil, err
+	}
+
+	delta := &Delta{
+		Previous:      previous.Taken,
+		NewAssets:     missingFrom(current.Assets, previous.Assets),
+		RemovedAssets: missingFrom(previous.Assets, current.Assets),
+		NewFindings:   missingFrom(current.Findings, previous.Findings),
+	}
+	s.alertDelta(delta)
+	return delta, nil
+}
+
+// currentSnapshot collects this run's assets and findings.
+func (s *Session) currentSnapshot() *snapshot {
+	snap := &snapshot{Taken: time.Now()}
+
+	seen := make(map[string]struct{})
+	for _, f := range support.SessionFindings(s.id) {
+		if _, found := seen[f.Asset]; !found {
+			seen[f.Asset] = struct{}{}
+			snap.Assets = append(snap.Assets, f.Asset)
+		}
+		snap.Findings = append(snap.Findings, f.Type+"|"+f.Asset)
+	}
+
+	sort.Strings(snap.Assets)
+	sort.Strings(snap.Findings)
+	return snap
+}
+
+// alertDelta raises one finding per change so the notification consumers
+// watching the findings stream see the summary.
+func (s *Session) alertDelta(delta *Delta) {
+	for _, asset := range delta.NewAssets {
+		support.AddFinding(s, &support.Finding{
+			Type:        "delta:new_asset",
+			Asset:       asset,
+			Description: asset + " appeared since the run of " + delta.Previous.Format(time.RFC3339),
+			Severity:    "info",
+			Source:      "delta",
+		})
+	}
+	for _, asset := range delta.RemovedAssets {
+		support.AddFinding(s, &support.Finding{
+			Type:        "delta:removed_asset",
+			Asset:       asset,
+			Description: asset + " disappeared since the run of " + delta.Previous.Format(time.RFC3339),
+			Severity:    "info",
+			Source:      "delta",
+		})
+	}
+
+	s.log.Info("computed the delta against the previous run",
+		"new", len(delta.NewAssets), "removed", len(delta.RemovedAssets),
+		"new_findings", len(delta.NewFindings))
+}
+
+// snapshotPath derives a stable per-scope location for the snapshot from
+// the session's configured domains.
+func (s *Session) snapshotPath() (string, error) {
+	dir, err := support.EngineCacheDir("snapshots")
+	if err != nil {
+		return "", err
+	}
+
+	domains := s.cfg.Domains()
+	sort.Strings(domains)
+	sum := sha256.Sum256([]byte(strings.Join(domains, ",")))
+	return filepath.Join(dir, hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+// missingFrom returns the sorted entries of a that b does not contain.
+func missingFrom(a, b []string) []string {
+	have := make(map[string]struct{}, len(b))
+	for _, v := range b {
+		have[v] = struct{}{}
+	}
+
+	var results []string
+	for _, v := range a {
+		if _, found := have[v]; !found {
+			results = append(results, v)
+		}
+	}
+	return results
+}
@@ -0,0 +1,70 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+// zoneRecordTypes maps the graph relations carrying DNS data onto their
+// resource record types.
+var zoneRecordTypes = map[string]string{
+	"a_record":     "A",
+	"aaaa_record":  "AAAA",
+	"cname_record": "CNAME",
+	"mx_record":    "MX",
+	"ns_record":    "NS",
+	"txt_record":   "TXT",
+}
+
+// ExportZone renders the DNS data collected for one in-scope zone as
+// standard zone-file formatted text, suitable for diffing against the
+// authoritative zone or feeding other DNS tooling.
+func (s *Session) ExportZone(zone string) (string, error) {
+	zone = strings.ToLower(strings.TrimSuffix(zone, "."))
+	if !s.cfg.IsDomainInScope(zone) {
+		return "", fmt.Errorf("the zone %s is not in the session scope", zone)
+	}
+
+	var lines []string
+	for _, r := range support.SessionRelations(s.id) {
+		rrtype, found := zoneRecordTypes[r.Relation]
+		if !found || s.cfg.WhichDomain(r.Source) != zone {
+			continue
+		}
+		lines = append(lines, zoneLine(r.Source, rrtype, r.Target))
+	}
+	if len(lines) == 0 {
+		return "", fmt.Errorf("no DNS records were collected for the zone %s", zone)
+	}
+	sort.Strings(lines)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "$ORIGIN %s.\n", zone)
+	fmt.Fprintf(&b, "; exported by the enumeration engine at %s\n", time.Now().Format(time.RFC3339))
+	b.WriteString(strings.Join(lines, "\n"))
+	b.WriteString("\n")
+	return b.String(), nil
+}
+
+// zoneLine renders one resource record in zone-file format.
+func zoneLine(owner, rrtype, data string) string {
+	switch rrtype {
+	case "CNAME", "NS":
+		data = data + "."
+	case "MX":
+		// The collected relation has no preference value; a placeholder
+		// keeps the output parseable.
+		data = "10 " + data + "."
+	case "TXT":
+		data = `"` + strings.ReplaceAll(data, `"`, `\"`) + `"`
+	}
+	return fmt.Sprintf("%s.\tIN\t%s\t%s", owner, rrtype, data)
+}
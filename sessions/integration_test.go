@@ -0,0 +1,91 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/owasp-amass/config/config"
+	"github.com/owasp-amass/engine/dispatcher"
+	"github.com/owasp-amass/engine/plugins/support"
+	"github.com/owasp-amass/engine/registry"
+	"github.com/owasp-amass/engine/sessions"
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// graphPlugin writes one discovered name into the session graph for every
+// in-scope FQDN it receives, standing in for the data source plugins.
+type graphPlugin struct {
+	discovered string
+}
+
+func (p *graphPlugin) Name() string { return "integration-test" }
+func (p *graphPlugin) Start(r types.Registry) error {
+	return r.RegisterHandler(&types.Handler{
+		Plugin:    p,
+		Name:      p.Name() + "-Handler",
+		EventType: oam.FQDN,
+		Callback:  p.check,
+	})
+}
+func (p *graphPlugin) Stop() {}
+
+func (p *graphPlugin) check(e *types.Event) error {
+	fqdn, ok := e.Asset.Asset.(*oamdns.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+	if e.Session.Config().WhichDomain(fqdn.Name) == "" {
+		return nil
+	}
+
+	_, err := support.Graph(e.Session).Create(nil, "", &oamdns.FQDN{Name: p.discovered})
+	return err
+}
+
+// TestEngineRunsSession drives the engine components end to end: a session
+// is created from a configuration, seeded from its scope, and the events
+// flow through the dispatcher into a handler whose graph writes land in the
+// session databases.
+func TestEngineRunsSession(t *testing.T) {
+	t.Setenv(support.WorkDirEnv, t.TempDir())
+
+	plugin := &graphPlugin{discovered: "www.example.com"}
+	reg := registry.NewRegistry(nil)
+	if err := plugin.Start(reg); err != nil {
+		t.Fatalf("failed to start the plugin: %v", err)
+	}
+	d := dispatcher.NewDispatcher(nil, reg)
+
+	cfg := config.NewConfig()
+	cfg.AddDomains("example.com")
+
+	s, err := sessions.NewSession(cfg, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create the session: %v", err)
+	}
+
+	mgr := sessions.NewManager(nil)
+	if err := mgr.AddSession(s); err != nil {
+		t.Fatalf("failed to add the session: %v", err)
+	}
+	defer mgr.Shutdown()
+
+	s.SeedScope(d)
+
+	want := &oamdns.FQDN{Name: plugin.discovered}
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if assets, err := s.DB().FindByContent(want, time.Time{}); err == nil && len(assets) > 0 {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatal("the discovered asset never reached the session database")
+}
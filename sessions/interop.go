@@ -0,0 +1,169 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+)
+
+// ExportTargets produces a target list directly consumable by httpx and
+// nuclei: one entry per in-scope host, annotated with the scheme and port
+// when the session observed a service there. A non-empty tag restricts the
+// list to hosts the analysts tagged with it.
+func (s *Session) ExportTargets(tag string) []string {
+	targets := make(map[string]struct{})
+
+	fqdns, err := s.db.FindByType(oam.FQDN, time.Time{})
+	if err != nil {
+		return nil
+	}
+	for _, a := range fqdns {
+		fqdn, ok := a.Asset.(*oamdns.FQDN)
+		if !ok || !s.cfg.IsDomainInScope(fqdn.Name) {
+			continue
+		}
+		// Hosts marked as false positives in earlier sessions stay on the
+		// graph but are kept out of the target list, whether the marking
+		// came through the suppression store or an analyst tag.
+		if support.IsSuppressed(s.cfg, fqdn.Name) ||
+			support.HasTag(s.cfg, fqdn.Name, support.FindingFalsePositive) {
+			continue
+		}
+		if tag != "" && !support.HasTag(s.cfg, fqdn.Name, tag) {
+			continue
+		}
+
+		annotated := false
+		for _, prop := range support.AssetProperties(s.id, fqdn.Name) {
+			switch prop.Name {
+			case "url:known":
+				// Already carries the scheme, host, and port.
+				targets[prop.Value] = struct{}{}
+				annotated = true
+			case "open_port":
+				if port, _, found := strings.Cut(prop.Value, "/"); found {
+					targets[fqdn.Name+":"+port] = struct{}{}
+					annotated = true
+				}
+			}
+		}
+		if !annotated {
+			targets[fqdn.Name] = struct{}{}
+		}
+	}
+
+	var results []string
+	for t := range targets {
+		results = append(results, t)
+	}
+	sort.Strings(results)
+	return results
+}
+
+// httpxResult is the subset of one httpx JSONL line the import consumes.
+type httpxResult struct {
+	URL        string   `json:"url"`
+	Host       string   `json:"host"`
+	Port       string   `json:"port"`
+	WebServer  string   `json:"webserver"`
+	Title      string   `json:"title"`
+	Tech       []string `json:"tech"`
+	StatusCode int      `json:"status_code"`
+}
+
+// ImportHTTPX reads httpx JSONL output and records the confirmed services
+// as asset properties. It returns how many lines were imported.
+func (s *Session) ImportHTTPX(r io.Reader) (int, error) {
+	var count int
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var result httpxResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			continue
+		}
+
+		host := strings.ToLower(result.Host)
+		if host == "" || !s.cfg.IsDomainInScope(host) {
+			continue
+		}
+		count++
+
+		if result.URL != "" {
+			support.AddProperty(s, host, "url:known", result.URL)
+		}
+		if result.WebServer != "" {
+			support.AddProperty(s, host, "service:banner", "http/"+result.Port+" "+result.WebServer)
+		}
+		for _, tech := range result.Tech {
+			support.AddProperty(s, host, "service:tech", tech)
+		}
+	}
+	return count, scanner.Err()
+}
+
+// nucleiResult is the subset of one nuclei JSONL line the import consumes.
+type nucleiResult struct {
+	TemplateID string `json:"template-id"`
+	Host       string `json:"host"`
+	MatchedAt  string `json:"matched-at"`
+	Info       struct {
+		Name     string `json:"name"`
+		Severity string `json:"severity"`
+	} `json:"info"`
+}
+
+// ImportNuclei reads nuclei JSONL output and records each match through
+// the findings layer. It returns how many lines were imported.
+func (s *Session) ImportNuclei(r io.Reader) (int, error) {
+	var count int
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		var result nucleiResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			continue
+		}
+
+		host := strings.ToLower(hostOnly(result.Host))
+		if host == "" || !s.cfg.IsDomainInScope(host) {
+			continue
+		}
+		count++
+
+		description := result.Info.Name
+		if result.MatchedAt != "" {
+			description += " matched at " + result.MatchedAt
+		}
+		support.AddFinding(s, &support.Finding{
+			Type:        "nuclei:" + result.TemplateID,
+			Asset:       host,
+			Description: description,
+			Severity:    result.Info.Severity,
+			Source:      "nuclei",
+		})
+	}
+	return count, scanner.Err()
+}
+
+// hostOnly strips the scheme and port nuclei may include in its host field.
+func hostOnly(host string) string {
+	if _, rest, found := strings.Cut(host, "://"); found {
+		host = rest
+	}
+	if h, _, found := strings.Cut(host, ":"); found {
+		host = h
+	}
+	return strings.TrimSuffix(host, "/")
+}
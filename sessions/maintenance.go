@@ -0,0 +1,140 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"net/netip"
+	"strings"
+	"time"
+
+	dbt "github.com/owasp-amass/asset-db/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	oamnet "github.com/owasp-amass/open-asset-model/network"
+)
+
+// MergeProposal describes one pair of assets the maintenance pass would
+// collapse into a single node.
+type MergeProposal struct {
+	Kept      string `json:"kept"`
+	Discarded string `json:"discarded"`
+	Reason    string `json:"reason"`
+}
+
+// MaintenanceReport summarizes a normalization and deduplication pass.
+type MaintenanceReport struct {
+	DryRun    bool             `json:"dry_run"`
+	Examined  int              `json:"examined"`
+	Proposals []*MergeProposal `json:"proposals"`
+}
+
+// mergePair carries the database records behind one proposal so the merge
+// can be applied without looking the assets up again.
+type mergePair struct {
+	kept      *dbt.Asset
+	discarded *dbt.Asset
+}
+
+// RunMaintenance normalizes and deduplicates the session's database:
+// case-folding FQDNs, collapsing equivalent IP representations, and merging
+// the duplicate assets that result. With dryRun set, it only reports the
+// merges it would perform.
+func (s *Session) RunMaintenance(dryRun bool) (*MaintenanceReport, error) {
+	report := &MaintenanceReport{DryRun: dryRun}
+
+	var pairs []*mergePair
+	canonical := make(map[string]*dbt.Asset)
+	propose := func(kept, discarded *dbt.Asset, reason string) {
+		pairs = append(pairs, &mergePair{kept: kept, discarded: discarded})
+		report.Proposals = append(report.Proposals, &MergeProposal{
+			Kept:      maintenanceName(kept),
+			Discarded: maintenanceName(discarded),
+			Reason:    reason,
+		})
+	}
+
+	if fqdns, err := s.db.FindByType(oam.FQDN, time.Time{}); err == nil {
+		for _, a := range fqdns {
+			fqdn, ok := a.Asset.(*oamdns.FQDN)
+			if !ok {
+				continue
+			}
+			report.Examined++
+
+			folded := strings.ToLower(strings.Trim(fqdn.Name, "."))
+			if prev, found := canonical[folded]; found {
+				propose(prev, a, "case-folded duplicate")
+			} else {
+				canonical[folded] = a
+			}
+		}
+	}
+
+	if ips, err := s.db.FindByType(oam.IPAddress, time.Time{}); err == nil {
+		for _, a := range ips {
+			ip, ok := a.Asset.(*oamnet.IPAddress)
+			if !ok {
+				continue
+			}
+			report.Examined++
+
+			// Re-parsing yields the canonical textual form.
+			addr, err := netip.ParseAddr(ip.Address.String())
+			if err != nil {
+				continue
+			}
+			key := "ip:" + addr.String()
+			if prev, found := canonical[key]; found {
+				propose(prev, a, "equivalent address representation")
+			} else {
+				canonical[key] = a
+			}
+		}
+	}
+
+	if !dryRun {
+		s.applyMerges(pairs)
+	}
+	return report, nil
+}
+
+// applyMerges collapses each discarded asset into its kept counterpart by
+// re-linking the discarded asset's relations before deleting it.
+func (s *Session) applyMerges(pairs []*mergePair) {
+	for _, p := range pairs {
+		if out, err := s.db.OutgoingRelations(p.discarded, time.Time{}); err == nil {
+			for _, r := range out {
+				if _, err := s.db.Link(p.kept, r.Type, r.ToAsset); err != nil {
+					s.log.Warn("failed to re-link an outgoing relation",
+						"kept", maintenanceName(p.kept), "relation", r.Type, "err", err)
+				}
+			}
+		}
+		if in, err := s.db.IncomingRelations(p.discarded, time.Time{}); err == nil {
+			for _, r := range in {
+				if _, err := s.db.Link(r.FromAsset, r.Type, p.kept); err != nil {
+					s.log.Warn("failed to re-link an incoming relation",
+						"kept", maintenanceName(p.kept), "relation", r.Type, "err", err)
+				}
+			}
+		}
+
+		if err := s.db.DeleteAsset(p.discarded.ID); err != nil {
+			s.log.Warn("failed to delete the merged asset",
+				"discarded", maintenanceName(p.discarded), "err", err)
+		}
+	}
+}
+
+// maintenanceName returns the display name for an asset in the report.
+func maintenanceName(a *dbt.Asset) string {
+	switch v := a.Asset.(type) {
+	case *oamdns.FQDN:
+		return v.Name
+	case *oamnet.IPAddress:
+		return v.Address.String()
+	}
+	return a.ID
+}
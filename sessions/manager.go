@@ -0,0 +1,96 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+// Manager tracks the sessions running within the engine.
+type Manager struct {
+	sync.RWMutex
+	log      *slog.Logger
+	sessions map[uuid.UUID]*Session
+}
+
+// NewManager returns an empty session manager.
+func NewManager(log *slog.Logger) *Manager {
+	if log == nil {
+		log = slog.Default()
+	}
+	return &Manager{
+		log:      log,
+		sessions: make(map[uuid.UUID]*Session),
+	}
+}
+
+// AddSession places the session under management.
+func (m *Manager) AddSession(s *Session) error {
+	if s == nil {
+		return errors.New("the session cannot be nil")
+	}
+
+	m.Lock()
+	defer m.Unlock()
+
+	if _, found := m.sessions[s.ID()]; found {
+		return errors.New("the session is already under management")
+	}
+	m.sessions[s.ID()] = s
+	go s.MonitorDiskUsage()
+	if !s.ReadOnly() {
+		go s.Revalidate()
+	}
+	return nil
+}
+
+// GetSession returns the identified session, or nil when not found.
+func (m *Manager) GetSession(id uuid.UUID) *Session {
+	m.RLock()
+	defer m.RUnlock()
+	return m.sessions[id]
+}
+
+// AllSessions returns every session currently under management.
+func (m *Manager) AllSessions() []*Session {
+	m.RLock()
+	defer m.RUnlock()
+
+	var results []*Session
+	for _, s := range m.sessions {
+		results = append(results, s)
+	}
+	return results
+}
+
+// RemoveSession terminates the identified session and drops it from
+// management.
+func (m *Manager) RemoveSession(id uuid.UUID) {
+	m.Lock()
+	defer m.Unlock()
+
+	if s, found := m.sessions[id]; found {
+		s.Kill()
+		delete(m.sessions, id)
+		support.CleanupSession(id)
+	}
+}
+
+// Shutdown terminates every session under management.
+func (m *Manager) Shutdown() {
+	m.Lock()
+	defer m.Unlock()
+
+	for id, s := range m.sessions {
+		s.Kill()
+		delete(m.sessions, id)
+		support.CleanupSession(id)
+	}
+}
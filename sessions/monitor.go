@@ -0,0 +1,85 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"io/fs"
+	"path/filepath"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+// Options bounding the on-disk footprint of a session, in megabytes.
+const (
+	dbSizeLimitOption       = "session/db_size_limit"
+	artifactSizeLimitOption = "session/artifact_size_limit"
+)
+
+// monitorInterval is how often the disk monitor samples directory sizes.
+const monitorInterval = time.Minute
+
+// warnFraction is the portion of a limit at which a warning is logged
+// before ingestion is actually paused.
+const warnFraction = 0.8
+
+// MonitorDiskUsage watches the session's database and artifact footprint,
+// logging a warning as a configured limit approaches and pausing ingestion
+// with an API-visible state when one is exceeded. It returns when the
+// session completes.
+func (s *Session) MonitorDiskUsage() {
+	dblimit := int64(support.OptionInt(s.cfg, dbSizeLimitOption, 0)) * 1024 * 1024
+	artlimit := int64(support.OptionInt(s.cfg, artifactSizeLimitOption, 0)) * 1024 * 1024
+	if dblimit <= 0 && artlimit <= 0 {
+		return
+	}
+
+	t := time.NewTicker(monitorInterval)
+	defer t.Stop()
+
+	warned := false
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-t.C:
+		}
+
+		total := dirSize(s.dir)
+		limit := dblimit
+		if artlimit > 0 {
+			limit += artlimit
+		}
+
+		switch {
+		case total >= limit:
+			if state, _ := s.IngestionState(); state == IngestionActive {
+				s.PauseIngestion("the session disk usage limit was exceeded")
+			}
+		case float64(total) >= float64(limit)*warnFraction:
+			if !warned {
+				warned = true
+				s.log.Warn("session disk usage is approaching the configured limit",
+					"used", total, "limit", limit)
+			}
+		}
+	}
+}
+
+// dirSize totals the file sizes beneath the provided directory.
+func dirSize(root string) int64 {
+	var total int64
+
+	_ = filepath.WalkDir(root, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
@@ -0,0 +1,71 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"errors"
+
+	"github.com/owasp-amass/config/config"
+)
+
+// Named performance/behavior profiles selectable per session. A profile
+// sets coherent defaults across resolver load, HTTP concurrency, scraping
+// depth, and brute-force budgets, so non-expert users do not have to tune
+// the individual options.
+const (
+	ProfileStealth    = "stealth"
+	ProfileNormal     = "normal"
+	ProfileAggressive = "aggressive"
+)
+
+// profileDefaults are the engine options each profile establishes. Options
+// already present in the configuration are left untouched, so explicit
+// settings always win over profile defaults.
+var profileDefaults = map[string]map[string]interface{}{
+	ProfileStealth: {
+		"dns/max_qps":           100,
+		"http/max_concurrency":  2,
+		"scrape/max_pages":      5,
+		"brute/max_guesses":     10000,
+		"session/bandwidth_cap": 100 * 1024 * 1024,
+	},
+	ProfileNormal: {
+		"dns/max_qps":          1000,
+		"http/max_concurrency": 10,
+		"scrape/max_pages":     20,
+		"brute/max_guesses":    250000,
+	},
+	ProfileAggressive: {
+		"dns/max_qps":          5000,
+		"http/max_concurrency": 50,
+		"scrape/max_pages":     50,
+		"brute/max_guesses":    5000000,
+	},
+}
+
+// ApplyProfile fills the configuration with the defaults of the named
+// profile, leaving explicitly configured options in place.
+func ApplyProfile(cfg *config.Config, name string) error {
+	defaults, found := profileDefaults[name]
+	if !found {
+		return errors.New("unknown configuration profile: " + name)
+	}
+
+	if cfg.Options == nil {
+		cfg.Options = make(map[string]interface{})
+	}
+	for key, value := range defaults {
+		if _, set := cfg.Options[key]; !set {
+			cfg.Options[key] = value
+		}
+	}
+	cfg.Options["profile"] = name
+	return nil
+}
+
+// ProfileNames returns the supported profile names.
+func ProfileNames() []string {
+	return []string{ProfileStealth, ProfileNormal, ProfileAggressive}
+}
@@ -0,0 +1,52 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	assetdb "github.com/owasp-amass/asset-db"
+	"github.com/owasp-amass/config/config"
+)
+
+// NewAnalysisSession attaches to an existing asset database in read-only
+// fashion: no enumeration runs and no plugin may modify the graph, while
+// the query, export, and analysis features remain available over the
+// historically collected data.
+func NewAnalysisSession(cfg *config.Config, db *assetdb.AssetDB) (*Session, error) {
+	if db == nil {
+		return nil, errors.New("an analysis session requires an existing database")
+	}
+
+	id := uuid.New()
+	dir := filepath.Join(os.TempDir(), "amass-engine", id.String())
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, err
+	}
+
+	s := &Session{
+		id:        id,
+		log:       slog.Default().With("session", id.String(), "mode", "analysis"),
+		cfg:       cfg,
+		db:        db,
+		cache:     db,
+		dir:       dir,
+		done:      make(chan struct{}),
+		ingestion: IngestionPaused,
+		readOnly:  true,
+	}
+	s.pauseReason = "read-only analysis session"
+	return s, nil
+}
+
+// ReadOnly returns true when the session only analyzes existing data and
+// must never modify the attached database.
+func (s *Session) ReadOnly() bool {
+	return s.readOnly
+}
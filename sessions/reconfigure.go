@@ -0,0 +1,39 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import "strings"
+
+// Reconfigure applies the selected parameter changes to the running
+// session: option values (rate limits, enabled plugins), additional scope
+// domains, and wordlist additions. Changes take effect immediately because
+// the scope engine and rate governors read the configuration on each use.
+func (s *Session) Reconfigure(options map[string]interface{}, addDomains, addWords []string) {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
+
+	if len(options) > 0 {
+		if s.cfg.Options == nil {
+			s.cfg.Options = make(map[string]interface{})
+		}
+		for key, value := range options {
+			s.cfg.Options[key] = value
+			s.log.Info("session option changed", "option", key, "value", value)
+		}
+	}
+
+	for _, domain := range addDomains {
+		if domain = strings.ToLower(strings.TrimSpace(domain)); domain != "" {
+			s.cfg.AddDomain(domain)
+			s.log.Info("domain added to the session scope", "domain", domain)
+		}
+	}
+
+	for _, word := range addWords {
+		if word = strings.ToLower(strings.TrimSpace(word)); word != "" {
+			s.cfg.AltWordlist = append(s.cfg.AltWordlist, word)
+		}
+	}
+}
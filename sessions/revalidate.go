@@ -0,0 +1,62 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+// revalidateAgeOption sets, in minutes, how old a validation may grow
+// before the background task re-checks the asset.
+const revalidateAgeOption = "session/revalidate_age"
+
+const defaultRevalidateAge = 6 * time.Hour
+
+// Revalidate periodically re-checks assets whose last validation has gone
+// stale during a long session, refreshing the timestamps of those still
+// present and marking disappeared assets as historical. It returns when
+// the session completes.
+func (s *Session) Revalidate() {
+	age := defaultRevalidateAge
+	if minutes := support.OptionInt(s.cfg, revalidateAgeOption, 0); minutes > 0 {
+		age = time.Duration(minutes) * time.Minute
+	}
+
+	t := time.NewTicker(age / 4)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-t.C:
+		}
+
+		for _, asset := range support.StaleAssets(s.id, age) {
+			if s.Done() {
+				return
+			}
+
+			if s.resolves(asset) {
+				support.MarkValidated(s, asset)
+			} else {
+				support.MarkHistorical(s, asset)
+			}
+		}
+	}
+}
+
+// resolves re-checks that the name still has address records.
+func (s *Session) resolves(name string) bool {
+	for _, qtype := range []uint16{dns.TypeA, dns.TypeAAAA} {
+		if rr, err := support.PerformQuery(s, name, qtype); err == nil && len(rr) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"bufio"
+	"net/netip"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	oamnet "github.com/owasp-amass/open-asset-model/network"
+)
+
+// seedBatchSize is how many seed assets are dispatched before the streamer
+// yields, keeping startup memory flat regardless of scope size.
+const seedBatchSize = 1000
+
+// SeedScope streams every seed from the session's configured scope into
+// the pipelines: the domain names first, then each configured netblock.
+func (s *Session) SeedScope(d types.Dispatcher) {
+	s.SeedDomains(d)
+
+	if s.cfg.Scope == nil {
+		return
+	}
+	for _, cidr := range s.cfg.Scope.CIDRStrings {
+		if err := s.SeedNetblock(d, cidr); err != nil {
+			s.log.Warn("failed to seed a netblock", "cidr", cidr, "err", err)
+		}
+	}
+}
+
+// SeedDomains streams the scope's domain names into the pipelines as FQDN
+// events without materializing them all upfront.
+func (s *Session) SeedDomains(d types.Dispatcher) {
+	domains := s.cfg.Domains()
+
+	for i, domain := range domains {
+		if s.Done() {
+			return
+		}
+		s.dispatchSeed(d, domain, &oamdns.FQDN{Name: domain})
+		if (i+1)%seedBatchSize == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+}
+
+// SeedNetblock lazily expands the provided CIDR, dispatching one IP address
+// event at a time. Whole netblocks never exist in memory as event slices,
+// so even large prefixes keep a flat footprint.
+func (s *Session) SeedNetblock(d types.Dispatcher, cidr string) error {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+	if err != nil {
+		return err
+	}
+
+	var count int
+	for addr := prefix.Addr(); prefix.Contains(addr); addr = addr.Next() {
+		if s.Done() {
+			return nil
+		}
+
+		ip := &oamnet.IPAddress{Address: addr, Type: addrType(addr)}
+		s.dispatchSeed(d, addr.String(), ip)
+
+		if count++; count%seedBatchSize == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	return nil
+}
+
+// SeedFile streams newline-delimited names from the provided file in
+// chunks, so enormous lists are never read into memory at once.
+func (s *Session) SeedFile(d types.Dispatcher, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var count int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if s.Done() {
+			return nil
+		}
+
+		name := strings.ToLower(strings.TrimSpace(scanner.Text()))
+		if name == "" || strings.HasPrefix(name, "#") {
+			continue
+		}
+		s.dispatchSeed(d, name, &oamdns.FQDN{Name: name})
+
+		if count++; count%seedBatchSize == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	return scanner.Err()
+}
+
+// dispatchSeed creates the asset in the session cache and hands it to the
+// dispatcher.
+func (s *Session) dispatchSeed(d types.Dispatcher, name string, asset oam.Asset) {
+	a, err := s.cache.Create(nil, "", asset)
+	if err != nil || a == nil {
+		return
+	}
+
+	e := types.AcquireEvent()
+	e.Name = name
+	e.Asset = a
+	e.Timestamp = time.Now()
+	e.Session = s
+	e.Dispatcher = d
+	_ = d.DispatchEvent(e)
+}
+
+// addrType returns the open-asset-model address type label for the address.
+func addrType(addr netip.Addr) string {
+	if addr.Is4() {
+		return "IPv4"
+	}
+	return "IPv6"
+}
@@ -0,0 +1,150 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"log/slog"
+	"sync"
+
+	"github.com/google/uuid"
+	assetdb "github.com/owasp-amass/asset-db"
+	"github.com/owasp-amass/config/config"
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+// Ingestion states exposed through the API.
+const (
+	IngestionActive = "active"
+	IngestionPaused = "paused"
+)
+
+// Session holds the state of a single enumeration: its configuration,
+// database handles, logger, and lifecycle signals.
+type Session struct {
+	id    uuid.UUID
+	log   *slog.Logger
+	cfg   *config.Config
+	db    *assetdb.AssetDB
+	cache *assetdb.AssetDB
+	dir   string
+
+	done     chan struct{}
+	doneOnce sync.Once
+
+	stateLock   sync.Mutex
+	ingestion   string
+	pauseReason string
+	readOnly    bool
+}
+
+// NewSession creates the session state for the provided configuration.
+// When no stores are passed in, they are opened from the configuration:
+// the configured primary database, with SQLite files in the session
+// directory as the default.
+func NewSession(cfg *config.Config, db, cache *assetdb.AssetDB) (*Session, error) {
+	id := uuid.New()
+
+	dir, err := support.EngineWorkDir(id.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if db == nil || cache == nil {
+		d, c, err := newStores(cfg, dir)
+		if err != nil {
+			return nil, err
+		}
+		if db == nil {
+			db = d
+		}
+		if cache == nil {
+			cache = c
+		}
+	}
+
+	return &Session{
+		id:        id,
+		log:       slog.Default().With("session", id.String()),
+		cfg:       cfg,
+		db:        db,
+		cache:     cache,
+		dir:       dir,
+		done:      make(chan struct{}),
+		ingestion: IngestionActive,
+	}, nil
+}
+
+// ID implements the types.Session interface.
+func (s *Session) ID() uuid.UUID {
+	return s.id
+}
+
+// Log implements the types.Session interface.
+func (s *Session) Log() *slog.Logger {
+	return s.log
+}
+
+// Config implements the types.Session interface.
+func (s *Session) Config() *config.Config {
+	return s.cfg
+}
+
+// DB implements the types.Session interface.
+func (s *Session) DB() *assetdb.AssetDB {
+	return s.db
+}
+
+// Cache implements the types.Session interface.
+func (s *Session) Cache() *assetdb.AssetDB {
+	return s.cache
+}
+
+// Dir returns the working directory holding session files and artifacts.
+func (s *Session) Dir() string {
+	return s.dir
+}
+
+// Done implements the types.Session interface.
+func (s *Session) Done() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Kill implements the types.Session interface.
+func (s *Session) Kill() {
+	s.doneOnce.Do(func() { close(s.done) })
+}
+
+// PauseIngestion stops the session from accepting new assets and records
+// the reason, which remains visible through the API.
+func (s *Session) PauseIngestion(reason string) {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
+
+	s.ingestion = IngestionPaused
+	s.pauseReason = reason
+	s.log.Warn("session ingestion paused", "reason", reason)
+}
+
+// ResumeIngestion allows the session to accept new assets again.
+func (s *Session) ResumeIngestion() {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
+
+	s.ingestion = IngestionActive
+	s.pauseReason = ""
+}
+
+// IngestionState returns the current ingestion state and, when paused, the
+// reason for the pause.
+func (s *Session) IngestionState() (string, string) {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
+	return s.ingestion, s.pauseReason
+}
@@ -0,0 +1,111 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+// crtshOrgEntry is one certificate row from a crt.sh organization search.
+type crtshOrgEntry struct {
+	CommonName string `json:"common_name"`
+	NameValue  string `json:"name_value"`
+}
+
+// bgpviewSearch is the subset of a BGPView search response consumed here.
+type bgpviewSearch struct {
+	Data struct {
+		IPv4Prefixes []struct {
+			Prefix string `json:"prefix"`
+			Name   string `json:"name"`
+		} `json:"ipv4_prefixes"`
+		IPv6Prefixes []struct {
+			Prefix string `json:"prefix"`
+			Name   string `json:"name"`
+		} `json:"ipv6_prefixes"`
+	} `json:"data"`
+}
+
+// WarmStart seeds the session from nothing but an organization name: it
+// runs certificate-organization and ASN registration lookups and proposes
+// the root domains and netblocks found as scope candidates. Nothing enters
+// active scope without approval, after which enumeration proceeds normally.
+// It returns how many candidates were proposed.
+func (s *Session) WarmStart(org string) (int, error) {
+	org = strings.TrimSpace(org)
+	if org == "" {
+		return 0, errors.New("an organization name is required for a warm start")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	client := support.NewAPIClient(support.AuthNone, "", "")
+	count := s.certOrgCandidates(ctx, client, org)
+	count += s.netblockCandidates(ctx, client, org)
+	return count, nil
+}
+
+// certOrgCandidates searches certificate transparency by the certificate
+// subject organization and proposes the registered domains found.
+func (s *Session) certOrgCandidates(ctx context.Context, client *support.APIClient, org string) int {
+	entries, err := support.GetJSON[[]crtshOrgEntry](ctx, client,
+		"https://crt.sh/?O="+url.QueryEscape(org)+"&output=json")
+	if err != nil {
+		s.log.Debug("the certificate organization search failed", "org", org, "err", err)
+		return 0
+	}
+
+	var count int
+	seen := make(map[string]struct{})
+	for _, entry := range *entries {
+		names := append(strings.Split(entry.NameValue, "\n"), entry.CommonName)
+		for _, name := range names {
+			root := support.RegisteredDomain(s.cfg, strings.TrimPrefix(strings.TrimSpace(name), "*."))
+			if root == "" {
+				continue
+			}
+			if _, found := seen[root]; found {
+				continue
+			}
+			seen[root] = struct{}{}
+
+			support.ProposeScopeCandidate(s, root, "warm-start",
+				"a certificate names the organization "+org)
+			count++
+		}
+	}
+	return count
+}
+
+// netblockCandidates searches ASN registration data for the organization
+// and proposes the announced prefixes.
+func (s *Session) netblockCandidates(ctx context.Context, client *support.APIClient, org string) int {
+	result, err := support.GetJSON[bgpviewSearch](ctx, client,
+		"https://api.bgpview.io/search?query_term="+url.QueryEscape(org))
+	if err != nil {
+		s.log.Debug("the ASN registration search failed", "org", org, "err", err)
+		return 0
+	}
+
+	var count int
+	for _, p := range result.Data.IPv4Prefixes {
+		support.ProposeScopeCandidate(s, p.Prefix, "warm-start",
+			"the prefix is registered to "+p.Name)
+		count++
+	}
+	for _, p := range result.Data.IPv6Prefixes {
+		support.ProposeScopeCandidate(s, p.Prefix, "warm-start",
+			"the prefix is registered to "+p.Name)
+		count++
+	}
+	return count
+}
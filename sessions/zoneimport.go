@@ -0,0 +1,143 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+	"time"
+
+	dbt "github.com/owasp-amass/asset-db/types"
+	"github.com/owasp-amass/engine/plugins/support"
+	oamdns "github.com/owasp-amass/open-asset-model/domain"
+	oamnet "github.com/owasp-amass/open-asset-model/network"
+)
+
+// zoneRecord is one resource record from an authoritative zone, in the
+// provider-neutral form the import consumes.
+type zoneRecord struct {
+	Zone   string
+	Name   string
+	Type   string
+	Values []string
+}
+
+// zoneProvider lists the authoritative zones a managed DNS provider hosts
+// for the organization, using the read-only credentials configured for it.
+type zoneProvider interface {
+	name() string
+	records(ctx context.Context) ([]zoneRecord, error)
+}
+
+// ImportAuthorizedZones pulls the organization's own authoritative DNS
+// zones from every managed DNS provider with configured credentials and
+// imports them as assets. The result is ground truth to diff external
+// discovery against: a record the enumeration never surfaced is a blind
+// spot, and a discovered host absent from the zones is worth a look. It
+// returns how many records were imported.
+func (s *Session) ImportAuthorizedZones() (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	var count int
+	var firstErr error
+	for _, provider := range s.zoneProviders() {
+		records, err := provider.records(ctx)
+		if err != nil {
+			s.log.Debug("the authoritative zone import failed",
+				"provider", provider.name(), "err", err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("%s: %w", provider.name(), err)
+			}
+			continue
+		}
+		count += s.importZoneRecords(provider.name(), records)
+	}
+
+	if count == 0 && firstErr != nil {
+		return 0, firstErr
+	}
+	return count, nil
+}
+
+// importZoneRecords enters the records into the asset database, marking
+// each with the provider that served it authoritatively.
+func (s *Session) importZoneRecords(provider string, records []zoneRecord) int {
+	g := support.Graph(s)
+	zones := make(map[string]struct{})
+
+	var count int
+	for _, record := range records {
+		name := cleanZoneName(record.Name)
+		zone := cleanZoneName(record.Zone)
+		if name == "" || zone == "" {
+			continue
+		}
+
+		// The provider credentials prove ownership of the zone, so the
+		// apex enters active scope directly rather than the approval queue.
+		if _, found := zones[zone]; !found {
+			zones[zone] = struct{}{}
+			if !s.cfg.IsDomainInScope(zone) {
+				s.cfg.AddDomain(zone)
+				s.log.Info("authoritative zone entered scope",
+					"zone", zone, "provider", provider)
+			}
+		}
+
+		src, err := g.Create(nil, "", &oamdns.FQDN{Name: name})
+		if err != nil {
+			continue
+		}
+		support.AddProperty(s, name,
+			"authoritative:"+strings.ToLower(record.Type), provider)
+		count++
+
+		for _, value := range record.Values {
+			s.importZoneValue(g, src, record.Type, value)
+		}
+	}
+	return count
+}
+
+// importZoneValue turns the record data into the graph relations the DNS
+// plugins would have created had they resolved the name themselves.
+func (s *Session) importZoneValue(g *support.GraphWriter, src *dbt.Asset, rtype, value string) {
+	value = strings.Trim(strings.TrimSpace(value), ".")
+	if value == "" {
+		return
+	}
+
+	switch strings.ToUpper(rtype) {
+	case "A", "AAAA":
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return
+		}
+		atype, relation := "IPv4", "a_record"
+		if addr.Is6() {
+			atype, relation = "IPv6", "aaaa_record"
+		}
+		g.Enqueue(src, relation, &oamnet.IPAddress{Address: addr, Type: atype})
+	case "CNAME":
+		g.Enqueue(src, "cname_record", &oamdns.FQDN{Name: strings.ToLower(value)})
+	case "NS":
+		g.Enqueue(src, "ns_record", &oamdns.FQDN{Name: strings.ToLower(value)})
+	case "MX":
+		fields := strings.Fields(value)
+		host := strings.Trim(fields[len(fields)-1], ".")
+		g.Enqueue(src, "mx_record", &oamdns.FQDN{Name: strings.ToLower(host)})
+	}
+}
+
+// cleanZoneName normalizes a provider-reported owner name, including the
+// escaped form Route 53 uses for wildcard labels.
+func cleanZoneName(name string) string {
+	name = strings.ToLower(strings.Trim(strings.TrimSpace(name), "."))
+	name = strings.ReplaceAll(name, `\052`, "*")
+	return strings.TrimPrefix(name, "*.")
+}
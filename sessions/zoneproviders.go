@@ -0,0 +1,349 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sessions
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/plugins/support"
+)
+
+// zoneProviders returns a client for each managed DNS provider the session
+// has credentials for. The credential layout per data source is:
+//
+//   - Cloudflare: apikey holds an API token with Zone.DNS read access
+//   - Route53: username holds the access key ID, password the secret key
+//   - AzureDNS: username holds the subscription ID, apikey an access token
+//   - GoogleCloudDNS: username holds the project ID, apikey an access token
+func (s *Session) zoneProviders() []zoneProvider {
+	var providers []zoneProvider
+
+	if cr := support.DataSourceCreds(s.cfg, "Cloudflare"); cr != nil && cr.Apikey != "" {
+		providers = append(providers, &cloudflareZones{token: cr.Apikey})
+	}
+	if cr := support.DataSourceCreds(s.cfg, "Route53"); cr != nil && cr.Username != "" && cr.Password != "" {
+		providers = append(providers, &route53Zones{accessKey: cr.Username, secretKey: cr.Password})
+	}
+	if cr := support.DataSourceCreds(s.cfg, "AzureDNS"); cr != nil && cr.Username != "" && cr.Apikey != "" {
+		providers = append(providers, &azureZones{subscription: cr.Username, token: cr.Apikey})
+	}
+	if cr := support.DataSourceCreds(s.cfg, "GoogleCloudDNS"); cr != nil && cr.Username != "" && cr.Apikey != "" {
+		providers = append(providers, &googleZones{project: cr.Username, token: cr.Apikey})
+	}
+	return providers
+}
+
+type cloudflareZones struct {
+	token string
+}
+
+type cloudflareZoneList struct {
+	Result []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"result"`
+}
+
+type cloudflareRecordList struct {
+	Result []struct {
+		Type    string `json:"type"`
+		Name    string `json:"name"`
+		Content string `json:"content"`
+	} `json:"result"`
+}
+
+func (c *cloudflareZones) name() string {
+	return "Cloudflare"
+}
+
+func (c *cloudflareZones) records(ctx context.Context) ([]zoneRecord, error) {
+	client := support.NewAPIClient(support.AuthBearer, "", c.token)
+
+	zones, err := support.GetJSON[cloudflareZoneList](ctx, client,
+		"https://api.cloudflare.com/client/v4/zones?per_page=50")
+	if err != nil {
+		return nil, err
+	}
+
+	var results []zoneRecord
+	for _, zone := range zones.Result {
+		list, err := support.GetJSON[cloudflareRecordList](ctx, client,
+			"https://api.cloudflare.com/client/v4/zones/"+zone.ID+"/dns_records?per_page=1000")
+		if err != nil {
+			return results, err
+		}
+		for _, record := range list.Result {
+			results = append(results, zoneRecord{
+				Zone:   zone.Name,
+				Name:   record.Name,
+				Type:   record.Type,
+				Values: []string{record.Content},
+			})
+		}
+	}
+	return results, nil
+}
+
+type route53Zones struct {
+	accessKey string
+	secretKey string
+}
+
+type route53ZoneList struct {
+	HostedZones []struct {
+		ID   string `xml:"Id"`
+		Name string `xml:"Name"`
+	} `xml:"HostedZones>HostedZone"`
+}
+
+type route53RecordList struct {
+	Sets []struct {
+		Name    string   `xml:"Name"`
+		Type    string   `xml:"Type"`
+		Records []string `xml:"ResourceRecords>ResourceRecord>Value"`
+	} `xml:"ResourceRecordSets>ResourceRecordSet"`
+}
+
+func (r *route53Zones) name() string {
+	return "Route53"
+}
+
+func (r *route53Zones) records(ctx context.Context) ([]zoneRecord, error) {
+	var zones route53ZoneList
+	if err := r.get(ctx, "/2013-04-01/hostedzone", &zones); err != nil {
+		return nil, err
+	}
+
+	var results []zoneRecord
+	for _, zone := range zones.HostedZones {
+		// The zone identifier arrives as "/hostedzone/<id>".
+		var sets route53RecordList
+		if err := r.get(ctx, "/2013-04-01"+zone.ID+"/rrset", &sets); err != nil {
+			return results, err
+		}
+		for _, set := range sets.Sets {
+			results = append(results, zoneRecord{
+				Zone:   zone.Name,
+				Name:   set.Name,
+				Type:   set.Type,
+				Values: set.Records,
+			})
+		}
+	}
+	return results, nil
+}
+
+// get performs a signed GET request against the Route 53 endpoint and
+// decodes the XML response.
+func (r *route53Zones) get(ctx context.Context, path string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		"https://route53.amazonaws.com"+path, nil)
+	if err != nil {
+		return err
+	}
+	signAWSRequest(req, r.accessKey, r.secretKey, "route53", "us-east-1")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return xml.NewDecoder(resp.Body).Decode(v)
+}
+
+// signAWSRequest applies AWS Signature Version 4 to the request. Only the
+// bodiless GET case the Route 53 calls need is implemented, which keeps
+// the engine free of the provider SDK.
+func signAWSRequest(req *http.Request, accessKey, secretKey, service, region string) {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	payloadHash := sha256.Sum256(nil)
+	canonicalHeaders := "host:" + req.URL.Host + "\nx-amz-date:" + amzDate + "\n"
+	signedHeaders := "host;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method, req.URL.EscapedPath(), req.URL.Query().Encode(),
+		canonicalHeaders, signedHeaders, hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := dateStamp + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256", amzDate, scope, hex.EncodeToString(requestHash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), dateStamp)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+accessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+type azureZones struct {
+	subscription string
+	token        string
+}
+
+type azureZoneList struct {
+	Value []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"value"`
+}
+
+type azureRecordList struct {
+	Value []struct {
+		Type       string `json:"type"`
+		Properties struct {
+			FQDN     string `json:"fqdn"`
+			ARecords []struct {
+				IPv4Address string `json:"ipv4Address"`
+			} `json:"ARecords"`
+			AAAARecords []struct {
+				IPv6Address string `json:"ipv6Address"`
+			} `json:"AAAARecords"`
+			CNAMERecord struct {
+				CNAME string `json:"cname"`
+			} `json:"CNAMERecord"`
+			NSRecords []struct {
+				NSDName string `json:"nsdname"`
+			} `json:"NSRecords"`
+			MXRecords []struct {
+				Exchange string `json:"exchange"`
+			} `json:"MXRecords"`
+		} `json:"properties"`
+	} `json:"value"`
+}
+
+func (a *azureZones) name() string {
+	return "AzureDNS"
+}
+
+func (a *azureZones) records(ctx context.Context) ([]zoneRecord, error) {
+	client := support.NewAPIClient(support.AuthBearer, "", a.token)
+
+	zones, err := support.GetJSON[azureZoneList](ctx, client,
+		"https://management.azure.com/subscriptions/"+a.subscription+
+			"/providers/Microsoft.Network/dnszones?api-version=2018-05-01")
+	if err != nil {
+		return nil, err
+	}
+
+	var results []zoneRecord
+	for _, zone := range zones.Value {
+		list, err := support.GetJSON[azureRecordList](ctx, client,
+			"https://management.azure.com"+zone.ID+"/all?api-version=2018-05-01")
+		if err != nil {
+			return results, err
+		}
+
+		for _, record := range list.Value {
+			// The record type arrives as "Microsoft.Network/dnszones/A".
+			rtype := record.Type[strings.LastIndex(record.Type, "/")+1:]
+
+			var values []string
+			p := record.Properties
+			switch strings.ToUpper(rtype) {
+			case "A":
+				for _, v := range p.ARecords {
+					values = append(values, v.IPv4Address)
+				}
+			case "AAAA":
+				for _, v := range p.AAAARecords {
+					values = append(values, v.IPv6Address)
+				}
+			case "CNAME":
+				values = append(values, p.CNAMERecord.CNAME)
+			case "NS":
+				for _, v := range p.NSRecords {
+					values = append(values, v.NSDName)
+				}
+			case "MX":
+				for _, v := range p.MXRecords {
+					values = append(values, v.Exchange)
+				}
+			}
+
+			results = append(results, zoneRecord{
+				Zone:   zone.Name,
+				Name:   p.FQDN,
+				Type:   rtype,
+				Values: values,
+			})
+		}
+	}
+	return results, nil
+}
+
+type googleZones struct {
+	project string
+	token   string
+}
+
+type googleZoneList struct {
+	ManagedZones []struct {
+		Name    string `json:"name"`
+		DNSName string `json:"dnsName"`
+	} `json:"managedZones"`
+}
+
+type googleRecordList struct {
+	Rrsets []struct {
+		Name    string   `json:"name"`
+		Type    string   `json:"type"`
+		Rrdatas []string `json:"rrdatas"`
+	} `json:"rrsets"`
+}
+
+func (g *googleZones) name() string {
+	return "GoogleCloudDNS"
+}
+
+func (g *googleZones) records(ctx context.Context) ([]zoneRecord, error) {
+	client := support.NewAPIClient(support.AuthBearer, "", g.token)
+	base := "https://dns.googleapis.com/dns/v1/projects/" + g.project + "/managedZones"
+
+	zones, err := support.GetJSON[googleZoneList](ctx, client, base)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []zoneRecord
+	for _, zone := range zones.ManagedZones {
+		list, err := support.GetJSON[googleRecordList](ctx, client, base+"/"+zone.Name+"/rrsets")
+		if err != nil {
+			return results, err
+		}
+		for _, set := range list.Rrsets {
+			results = append(results, zoneRecord{
+				Zone:   zone.DNSName,
+				Name:   set.Name,
+				Type:   set.Type,
+				Values: set.Rrdatas,
+			})
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,42 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import "sync"
+
+// Brute-force bursts allocate events faster than the garbage collector can
+// keep up with, causing latency spikes across the pipelines. Pooling lets
+// the dispatcher recycle events once their handlers have finished.
+var eventPool = sync.Pool{
+	New: func() interface{} { return new(Event) },
+}
+
+// AcquireEvent returns a cleared event from the pool. Events obtained here
+// are returned to the pool by the dispatcher after the last handler runs,
+// so callers must not retain them past dispatch.
+func AcquireEvent() *Event {
+	e := eventPool.Get().(*Event)
+	e.pooled = true
+	return e
+}
+
+// DisownEvent removes the event from pool management, leaving it to the
+// garbage collector. Used when a handler may still hold a reference past
+// the end of its dispatch.
+func DisownEvent(e *Event) {
+	if e != nil {
+		e.pooled = false
+	}
+}
+
+// ReleaseEvent clears the event and returns it to the pool. Events built
+// directly with a composite literal are left for the garbage collector.
+func ReleaseEvent(e *Event) {
+	if e == nil || !e.pooled {
+		return
+	}
+	*e = Event{}
+	eventPool.Put(e)
+}
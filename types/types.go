@@ -0,0 +1,103 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package types
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	assetdb "github.com/owasp-amass/asset-db"
+	dbt "github.com/owasp-amass/asset-db/types"
+	"github.com/owasp-amass/config/config"
+	oam "github.com/owasp-amass/open-asset-model"
+)
+
+// Event represents a single unit of work moving through the engine pipelines.
+type Event struct {
+	// ID uniquely identifies the event across log lines; the dispatcher
+	// assigns one on entry when the creator did not.
+	ID         uuid.UUID
+	Name       string
+	Asset      *dbt.Asset
+	Meta       interface{}
+	Timestamp  time.Time
+	Session    Session
+	Dispatcher Dispatcher
+
+	// Correlation carries the client-supplied correlation ID from a seed
+	// asset down through every event derived from it, so API clients can
+	// be told when the subtree rooted at their submission has completed.
+	Correlation string
+
+	// pooled marks events obtained from AcquireEvent so ReleaseEvent only
+	// recycles memory the pool handed out.
+	pooled bool
+
+	// log is the event-scoped logger the dispatcher attaches before each
+	// handler runs, carrying the session, event ID, asset, and plugin.
+	log *slog.Logger
+}
+
+// SetLogger attaches the event-scoped logger; the dispatcher calls this
+// before each handler invocation.
+func (e *Event) SetLogger(l *slog.Logger) {
+	e.log = l
+}
+
+// Logger returns the event-scoped logger, falling back to the session
+// logger for events that never passed through the dispatcher middleware.
+func (e *Event) Logger() *slog.Logger {
+	if e.log != nil {
+		return e.log
+	}
+	if e.Session != nil {
+		return e.Session.Log()
+	}
+	return slog.Default()
+}
+
+// Session is implemented by the session manager and provides plugins
+// access to per-enumeration state and storage.
+type Session interface {
+	ID() uuid.UUID
+	Log() *slog.Logger
+	Config() *config.Config
+	DB() *assetdb.AssetDB
+	Cache() *assetdb.AssetDB
+	ReadOnly() bool
+	Done() bool
+	Kill()
+}
+
+// Dispatcher accepts events and routes them to the registered handlers.
+type Dispatcher interface {
+	DispatchEvent(e *Event) error
+}
+
+// Plugin is implemented by all engine plugins.
+type Plugin interface {
+	Name() string
+	Start(r Registry) error
+	Stop()
+}
+
+// Handler represents a single plugin callback registered for an asset type.
+type Handler struct {
+	Plugin       Plugin
+	Name         string
+	Priority     int
+	MaxInstances int
+	Transforms   []string
+	EventType    oam.AssetType
+	Callback     func(*Event) error
+}
+
+// Registry is the interface plugins use to register their handlers.
+type Registry interface {
+	Log() *slog.Logger
+	RegisterHandler(h *Handler) error
+	DeregisterHandler(eventType oam.AssetType, name string) error
+}